@@ -1,42 +1,98 @@
 package audio
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
+	"sync"
 	"time"
 
 	"github.com/faiface/beep"
 	"github.com/faiface/beep/effects"
 	"github.com/faiface/beep/speaker"
-	"github.com/faiface/beep/wav"
 	"github.com/sirupsen/logrus"
-	"go.uber.org/multierr"
 )
 
 const (
 	targetSampleRate beep.SampleRate = 41000
-	resampleQuality                  = 3
+
+	defaultPrebuffer   = 500 * time.Millisecond
+	streamBufferLength = 30 * time.Second
+
+	// streamHistoryLength is how much of the track that has already been played
+	// is kept around for seeking back
+	streamHistoryLength = 15 * time.Second
+
+	// skipFadeLength is how long it takes to fade out a track that was skipped
+	skipFadeLength = 500 * time.Millisecond
 )
 
 var ffmpegArgs = []string{
-	"-y",                                 // Yes to All
 	"-hide_banner", "-loglevel", "panic", // Be Quiet
 	"-i", "pipe:0", // Input from stdin
-	"-c:a", "pcm_s16le", // PCM Signed 16-bit Little Endian output
-	"-f", "wav", // Output WAV
+	"-f", "s16le", "-c:a", "pcm_s16le", // Raw PCM Signed 16-bit Little Endian output
+	"-ac", fmt.Sprint(pcmChannels), // Stereo
+	"-ar", fmt.Sprint(int(targetSampleRate)), // At the speaker's sample rate
+	"pipe:1", // Output to stdout
 }
 
-type beepFFmpegPlayer struct {
-	ffmpeg string
+// Option configures a player created with NewBeepFFmpegPipeline
+type Option func(b *beepFFmpegPlayer)
+
+// WithPrebuffer sets how much audio is decoded before playback of a track
+// starts. Larger values avoid stuttering on slow links at the cost of a longer
+// delay between tracks.
+func WithPrebuffer(d time.Duration) Option {
+	return func(b *beepFFmpegPlayer) {
+		if d >= 0 {
+			b.prebuffer = d
+		}
+	}
+}
+
+// WithTransport downloads tracks through rt instead of the default transport,
+// for example to use the same proxy as the pandora client
+func WithTransport(rt http.RoundTripper) Option {
+	return func(b *beepFFmpegPlayer) {
+		b.http = &http.Client{Transport: rt}
+	}
+}
+
+// WithCrossfade fades each track into the next over d. Tracks are played
+// back to back without a gap if d is 0.
+func WithCrossfade(d time.Duration) Option {
+	return func(b *beepFFmpegPlayer) {
+		if d >= 0 {
+			b.crossfade = d
+		}
+	}
+}
 
-	transcodedTrack     *os.File
-	nowStreaming        beep.StreamSeekCloser
-	streamingSampleRate beep.SampleRate
-	ctrl                *beep.Ctrl
+type beepFFmpegPlayer struct {
+	ffmpeg    string
+	http      *http.Client
+	prebuffer time.Duration
+	crossfade time.Duration
+
+	// current is the voice reported on DoneChan when it ends, incoming is the
+	// preloaded voice started early to fade into it. Both are protected by the
+	// speaker lock.
+	current      *voice
+	incoming     *voice
+	nowStreaming *pcmStream
+	mixer        *beep.Mixer
+	ctrl         *beep.Ctrl
+
+	// master applies the user's volume to everything coming out of the mixer.
+	// It and the volume settings are protected by the speaker lock.
+	master *effects.Volume
+	volume int
+	muted  bool
+
+	preloadLock sync.Mutex
+	next        *transcoding
 
 	progressTicker *time.Ticker
 	progress       chan PlaybackProgress
@@ -46,10 +102,11 @@ type beepFFmpegPlayer struct {
 }
 
 // NewBeepFFmpegPipeline returns an audio.Player that transcodes tracks through FFmpeg
-// via exec.Command to PCM and then plays audio via speaker.Play. Tracks must be fully
-// transcoded first otherwise wav.Decode will refuse to play them. Because of this,
-// UpdateStream will block until transcoding is complete.
-func NewBeepFFmpegPipeline() (*beepFFmpegPlayer, error) {
+// via exec.Command to raw PCM and then plays audio via speaker.Play. Playback starts
+// as soon as enough of the track has been decoded to fill the pre-buffer, the rest of
+// the track is decoded while it plays. Tracks are mixed together so that a preloaded
+// track can start as soon as the current one ends or fade into it.
+func NewBeepFFmpegPipeline(opts ...Option) (*beepFFmpegPlayer, error) {
 	ffmpeg, err := exec.LookPath("ffmpeg")
 	if err != nil {
 		return nil, fmt.Errorf("could not locate ffmpeg on $PATH: %w", err)
@@ -59,10 +116,17 @@ func NewBeepFFmpegPipeline() (*beepFFmpegPlayer, error) {
 		return nil, fmt.Errorf("failed to init beep speaker: %w", err)
 	}
 
+	mixer := &beep.Mixer{}
+	master := &effects.Volume{Base: 10, Streamer: mixer}
 	result := &beepFFmpegPlayer{
-		ffmpeg: ffmpeg,
+		ffmpeg:    ffmpeg,
+		http:      http.DefaultClient,
+		prebuffer: defaultPrebuffer,
 
-		ctrl: &beep.Ctrl{Paused: true},
+		mixer:  mixer,
+		master: master,
+		volume: MaxVolume,
+		ctrl:   &beep.Ctrl{Streamer: master, Paused: true},
 
 		progressTicker: time.NewTicker(1 * time.Second),
 		progress:       make(chan PlaybackProgress, 1),
@@ -71,10 +135,17 @@ func NewBeepFFmpegPipeline() (*beepFFmpegPlayer, error) {
 		log: logrus.WithField("prefix", "ffmpeg"),
 	}
 
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	applyVolume(result.master, result.volume, result.muted)
+	speaker.Play(result.ctrl)
+
 	go func() {
 		for range result.progressTicker.C {
-			if result.nowStreaming != nil {
-				result.progress <- result.calculateProgress()
+			if p, ok := result.calculateProgress(); ok {
+				result.progress <- p
 			}
 		}
 	}()
@@ -82,19 +153,18 @@ func NewBeepFFmpegPipeline() (*beepFFmpegPlayer, error) {
 	return result, nil
 }
 
-func (b *beepFFmpegPlayer) cleanup() (err error) {
-	if b.transcodedTrack != nil {
-		err = multierr.Combine(
-			b.nowStreaming.Close(),
-			b.transcodedTrack.Close(),
-			os.Remove(b.transcodedTrack.Name()),
-		)
-
-		b.nowStreaming = nil
-		b.transcodedTrack = nil
+// retire fades out everything that is playing. The caller must hold the
+// speaker lock.
+func (b *beepFFmpegPlayer) retire() {
+	for _, v := range []*voice{b.current, b.incoming} {
+		if v != nil {
+			v.fadeOut(targetSampleRate.N(skipFadeLength))
+		}
 	}
 
-	return err
+	b.current = nil
+	b.incoming = nil
+	b.nowStreaming = nil
 }
 
 func (b *beepFFmpegPlayer) Close() error {
@@ -103,59 +173,297 @@ func (b *beepFFmpegPlayer) Close() error {
 
 	speaker.Close()
 	b.progressTicker.Stop()
-	return b.cleanup()
+
+	for _, v := range []*voice{b.current, b.incoming} {
+		if v != nil {
+			v.stop()
+		}
+	}
+
+	b.current = nil
+	b.incoming = nil
+	b.CancelPreload()
+
+	return nil
 }
 
-func (b *beepFFmpegPlayer) UpdateStream(url string, volumeAdjustment float64) {
-	// Stop playing anything currently playing
-	speaker.Clear()
-	b.ctrl.Paused = true
+func (b *beepFFmpegPlayer) UpdateStream(url string, volumeAdjustment float64, length time.Duration) {
+	speaker.Lock()
+
+	// Anything that wasn't picked up from DoneChan yet is about a track that
+	// is no longer playing, like one that ended just as it was skipped
+	select {
+	case <-b.done:
+	default:
+	}
 
-	// Clean up if we were previously playing something
-	_ = b.cleanup()
+	// If the track was already started to fade into the last one, just keep
+	// playing it
+	if b.incoming != nil && b.incoming.t.url == url {
+		b.log.WithField("track", url).Debug("Track already playing")
+
+		b.current, b.incoming = b.incoming, nil
+		b.current.volume.Volume = volumeAdjustment / 10
+		b.nowStreaming = b.current.stream
+		b.ctrl.Paused = false
+		speaker.Unlock()
+
+		b.resetProgress()
+		return
+	}
+
+	// Otherwise fade out anything currently playing
+	b.retire()
+	speaker.Unlock()
+
+	// Use the preloaded track if we have one, otherwise start transcoding to PCM
+	b.preloadLock.Lock()
+	t := b.next
+	b.next = nil
+	b.preloadLock.Unlock()
+
+	if t != nil && t.url == url {
+		b.log.WithField("track", url).Debug("Using preloaded track")
+	} else {
+		if t != nil {
+			t.Close()
+		}
 
-	// Transcode to WAV
-	var err error
-	b.transcodedTrack, err = b.transcode(url)
+		t = b.startTranscoding(url, volumeAdjustment, length)
+	}
+
+	stream, err := t.wait()
 	if err != nil {
+		t.Close()
 		b.log.WithError(err).Errorf("Transcoding failed")
-		b.done <- err
+		b.finished(err)
 		return
 	}
 
-	// Decode
-	var format beep.Format
-	b.nowStreaming, format, err = wav.Decode(b.transcodedTrack)
-	if err != nil {
-		b.log.WithError(err).Errorf("Could not decode transcoded file")
-		b.done <- err
+	// Give ffmpeg a head start so we don't immediately run out of audio
+	stream.waitBuffered(targetSampleRate.N(b.prebuffer))
+	if stream.Len() == 0 && stream.Err() != nil {
+		t.Close()
+		b.log.WithError(stream.Err()).Errorf("Could not decode track")
+		b.finished(stream.Err())
 		return
 	}
 
 	b.log.WithFields(logrus.Fields{
-		"sampleRate": format.SampleRate,
-		"channels":   format.NumChannels,
+		"prebuffer":  b.prebuffer,
+		"length":     length,
 		"replayGain": volumeAdjustment,
-	}).Debug("Decoded track")
+	}).Debug("Streaming track")
+
+	// Play!
+	speaker.Lock()
+	b.current = b.newVoice(t, stream, volumeAdjustment)
+	b.nowStreaming = stream
+	b.mixer.Add(b.current)
+	b.ctrl.Paused = false
+	speaker.Unlock()
+
+	b.resetProgress()
+}
+
+// FadeOut quickly fades out the current track, used when it is skipped
+func (b *beepFFmpegPlayer) FadeOut() {
+	b.log.WithFields(logrus.Fields{}).Trace("Asked to fade out")
+
+	speaker.Lock()
+	defer speaker.Unlock()
+
+	b.retire()
+}
+
+func (b *beepFFmpegPlayer) Seek(position time.Duration) {
+	b.log.WithField("position", position).Trace("Asked to seek")
+
+	speaker.Lock()
+	v := b.current
+	if v == nil {
+		speaker.Unlock()
+		return
+	}
+
+	target := targetSampleRate.N(position)
+	if err := v.stream.Seek(target); err == nil {
+		speaker.Unlock()
+		b.resetProgress()
+		return
+	}
+
+	old := v.t
+	speaker.Unlock()
+
+	// We've already thrown away that part of the track, start transcoding it
+	// again in the background since it has to be downloaded again
+	b.log.WithField("position", position).Debug("Seek position no longer buffered, restarting track")
+	go b.restartAt(v, old, target)
+}
+
+// restartAt transcodes the track v is playing again and skips ahead to frame
+// target as it is decoded. If the track changed in the meantime, the new
+// transcoding is discarded.
+func (b *beepFFmpegPlayer) restartAt(v *voice, old *transcoding, target int) {
+	t := b.startTranscoding(old.url, old.volumeAdjustment, old.length)
+	stream, err := t.wait()
+	if err != nil {
+		t.Close()
+		b.log.WithError(err).Error("Failed to restart track")
+		return
+	}
+
+	_ = stream.Seek(target)
+
+	speaker.Lock()
+	if v != b.current || v.t != old {
+		// The track changed while we were restarting it
+		speaker.Unlock()
+		t.Close()
+		return
+	}
+
+	v.replace(t, stream)
+	b.nowStreaming = stream
+	speaker.Unlock()
+
+	b.resetProgress()
+}
+
+func (b *beepFFmpegPlayer) SeekBy(offset time.Duration) {
+	speaker.Lock()
+	v := b.current
+	if v == nil {
+		speaker.Unlock()
+		return
+	}
+
+	position := targetSampleRate.D(v.stream.Position()) + offset
+	speaker.Unlock()
+
+	if position < 0 {
+		position = 0
+	}
+
+	b.Seek(position)
+}
+
+func (b *beepFFmpegPlayer) newVoice(t *transcoding, stream *pcmStream, volumeAdjustment float64) *voice {
+	v := newVoice(t, stream, volumeAdjustment)
+	v.progressed = b.voiceProgressed
+	v.ended = b.voiceEnded
+
+	return v
+}
+
+// voiceProgressed starts fading the preloaded track in once the current track
+// is within the crossfade length of its end. It is called by the speaker with
+// the speaker lock held.
+func (b *beepFFmpegPlayer) voiceProgressed(v *voice) {
+	if v != b.current || b.crossfade == 0 || v.fadingOut() {
+		return
+	}
+
+	remaining, ok := v.stream.remaining()
+	if !ok || remaining > targetSampleRate.N(b.crossfade) {
+		return
+	}
 
-	// Setup pipeline
-	b.streamingSampleRate = format.SampleRate
-	b.ctrl.Streamer = beep.Resample(resampleQuality, b.streamingSampleRate, targetSampleRate, &effects.Volume{
-		Base:     10,
-		Volume:   volumeAdjustment / 10,
-		Streamer: b.nowStreaming,
-	})
+	if b.startPreloaded(remaining) {
+		b.log.WithField("remaining", targetSampleRate.D(remaining)).Debug("Crossfading into preloaded track")
 
-	// Reset progress
+		v.fadeOut(remaining)
+		b.current = nil
+		b.finished(nil)
+	}
+}
+
+// voiceEnded starts the preloaded track as soon as the current track ends so
+// there is no gap between them. It is called by the speaker with the speaker
+// lock held.
+func (b *beepFFmpegPlayer) voiceEnded(v *voice) {
+	if v != b.current {
+		return
+	}
+
+	b.current = nil
+	b.startPreloaded(0)
+	b.finished(v.Err())
+}
+
+// finished reports on DoneChan that the current track is done playing. It
+// never blocks since it may be called by the speaker with the speaker lock
+// held. If the end of a track is already waiting to be picked up, err is
+// dropped.
+func (b *beepFFmpegPlayer) finished(err error) {
+	select {
+	case b.done <- err:
+	default:
+		b.log.WithError(err).Debug("Track end already reported")
+	}
+}
+
+// startPreloaded adds the preloaded track to the mixer, fading it in over n
+// samples, if it is ready to play. The caller must hold the speaker lock.
+func (b *beepFFmpegPlayer) startPreloaded(n int) bool {
+	b.preloadLock.Lock()
+	defer b.preloadLock.Unlock()
+
+	if b.next == nil {
+		return false
+	}
+
+	select {
+	case <-b.next.ready:
+	default:
+		return false
+	}
+
+	if b.next.err != nil {
+		return false
+	}
+
+	b.incoming = b.newVoice(b.next, b.next.stream, b.next.volumeAdjustment)
+	b.incoming.fadeIn(n)
+	b.mixer.Add(b.incoming)
+	b.next = nil
+
+	return true
+}
+
+func (b *beepFFmpegPlayer) resetProgress() {
 	b.progressTicker.Reset(1 * time.Second)
-	b.progress <- b.calculateProgress()
 
-	// Play!
-	speaker.Play(beep.Seq(b.ctrl, beep.Callback(func() {
-		b.done <- nil
-	})))
+	p, _ := b.calculateProgress()
+	b.progress <- p
+}
 
-	b.ctrl.Paused = false
+func (b *beepFFmpegPlayer) Preload(url string, volumeAdjustment float64, length time.Duration) {
+	b.preloadLock.Lock()
+	defer b.preloadLock.Unlock()
+
+	if b.next != nil {
+		if b.next.url == url {
+			return
+		}
+
+		b.next.Close()
+	}
+
+	b.log.WithField("track", url).Debug("Preloading track")
+	b.next = b.startTranscoding(url, volumeAdjustment, length)
+}
+
+func (b *beepFFmpegPlayer) CancelPreload() {
+	b.preloadLock.Lock()
+	defer b.preloadLock.Unlock()
+
+	if b.next != nil {
+		b.log.WithField("track", b.next.url).Debug("Cancelling preload")
+		b.next.Close()
+		b.next = nil
+	}
 }
 
 func (b *beepFFmpegPlayer) Play() {
@@ -176,6 +484,40 @@ func (b *beepFFmpegPlayer) Pause() {
 	b.ctrl.Paused = true
 }
 
+func (b *beepFFmpegPlayer) SetVolume(percent int) {
+	b.log.WithField("volume", percent).Trace("Asked to set volume")
+
+	speaker.Lock()
+	defer speaker.Unlock()
+
+	b.volume = clampVolume(percent)
+	applyVolume(b.master, b.volume, b.muted)
+}
+
+func (b *beepFFmpegPlayer) SetMuted(muted bool) {
+	b.log.WithField("muted", muted).Trace("Asked to mute")
+
+	speaker.Lock()
+	defer speaker.Unlock()
+
+	b.muted = muted
+	applyVolume(b.master, b.volume, b.muted)
+}
+
+func (b *beepFFmpegPlayer) Volume() int {
+	speaker.Lock()
+	defer speaker.Unlock()
+
+	return b.volume
+}
+
+func (b *beepFFmpegPlayer) IsMuted() bool {
+	speaker.Lock()
+	defer speaker.Unlock()
+
+	return b.muted
+}
+
 func (b *beepFFmpegPlayer) IsPlaying() bool {
 	speaker.Lock()
 	defer speaker.Unlock()
@@ -192,66 +534,116 @@ func (b *beepFFmpegPlayer) DoneChan() <-chan error {
 	return b.done
 }
 
-func (b *beepFFmpegPlayer) transcode(url string) (*os.File, error) {
-	b.log.WithField("track", url).Debug("Attempting to transcode track")
+// transcoding is a track being fetched and decoded in the background
+type transcoding struct {
+	url              string
+	volumeAdjustment float64
+	length           time.Duration
+	stop             context.CancelFunc
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("transcode: failed to fetch track: %w", err)
+	ready  chan struct{}
+	stream *pcmStream
+	err    error
+}
+
+// startTranscoding starts transcoding url without waiting for the track to
+// start downloading
+func (b *beepFFmpegPlayer) startTranscoding(url string, volumeAdjustment float64, length time.Duration) *transcoding {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &transcoding{
+		url:              url,
+		volumeAdjustment: volumeAdjustment,
+		length:           length,
+		stop:             cancel,
+		ready:            make(chan struct{}),
 	}
 
-	defer func() {
-		_ = resp.Body.Close()
+	go func() {
+		defer close(t.ready)
+		t.stream, t.err = b.transcode(ctx, url, length)
 	}()
 
-	tmp, err := ioutil.TempFile(os.TempDir(), "mousiki")
-	if err != nil {
-		return nil, fmt.Errorf("transcode: failed to create temp file: %w", err)
-	}
-	_ = tmp.Close()
+	return t
+}
 
-	b.log.WithField("file", tmp.Name()).Debug("Transcoding Track")
+// wait blocks until ffmpeg has started decoding the track
+func (t *transcoding) wait() (*pcmStream, error) {
+	<-t.ready
+	return t.stream, t.err
+}
 
-	cmd := exec.Command(b.ffmpeg, append(ffmpegArgs, tmp.Name())...)
+// Close stops transcoding and discards any decoded audio
+func (t *transcoding) Close() {
+	t.stop()
 
-	cmd.Stderr = os.Stderr
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, fmt.Errorf("transcode: ffmpeg: failed to create stdin pipe: %w", err)
+	if stream, _ := t.wait(); stream != nil {
+		_ = stream.Close()
 	}
+}
 
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("transcode: ffmpeg: transcoding failed")
-	}
+// transcode starts fetching url and piping it through ffmpeg in the background.
+// The returned stream is fed with decoded audio until the track ends or ctx is
+// cancelled.
+func (b *beepFFmpegPlayer) transcode(ctx context.Context, url string, length time.Duration) (*pcmStream, error) {
+	b.log.WithField("track", url).Debug("Attempting to transcode track")
 
-	n, err := io.Copy(stdin, resp.Body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("transcode: ffmpeg: failed to transcode track: %w", err)
+		return nil, fmt.Errorf("transcode: failed to create request: %w", err)
 	}
 
-	b.log.WithFields(logrus.Fields{
-		"file": tmp.Name(),
-		"len":  n,
-	}).Debug("Transcoding complete")
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("transcode: failed to fetch track: %w", err)
+	}
 
-	if err := stdin.Close(); err != nil {
-		return nil, fmt.Errorf("transcode: ffmpeg: failed to close stdin: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("transcode: failed to fetch track: unexpected status %s", resp.Status)
 	}
 
-	if err := cmd.Wait(); err != nil {
-		return nil, fmt.Errorf("transcode: ffmpeg: unknown transcoding error: %w", err)
+	stream := newPCMStream(targetSampleRate.N(streamBufferLength), targetSampleRate.N(streamHistoryLength), targetSampleRate.N(length))
+
+	cmd := exec.CommandContext(ctx, b.ffmpeg, ffmpegArgs...)
+	cmd.Stdin = resp.Body
+	cmd.Stdout = stream
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("transcode: ffmpeg: failed to start: %w", err)
 	}
 
-	return os.Open(tmp.Name())
+	go func() {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		if err := cmd.Wait(); err != nil {
+			stream.finish(fmt.Errorf("transcode: ffmpeg: transcoding failed: %w", err))
+			return
+		}
+
+		b.log.WithField("track", url).Debug("Transcoding complete")
+		stream.finish(nil)
+	}()
+
+	return stream, nil
 }
 
-func (b *beepFFmpegPlayer) calculateProgress() PlaybackProgress {
+// calculateProgress reports how far into the track that is streaming playback
+// is, or false if nothing is streaming. It takes the speaker lock, so the
+// caller must not hold it.
+func (b *beepFFmpegPlayer) calculateProgress() (PlaybackProgress, bool) {
+	speaker.Lock()
+	defer speaker.Unlock()
+
 	if b.nowStreaming == nil {
-		return PlaybackProgress{}
+		return PlaybackProgress{}, false
 	}
 
 	return PlaybackProgress{
-		Duration: b.streamingSampleRate.D(b.nowStreaming.Len()),
-		Progress: b.streamingSampleRate.D(b.nowStreaming.Position()),
-	}
+		Duration: targetSampleRate.D(b.nowStreaming.Len()),
+		Progress: targetSampleRate.D(b.nowStreaming.Position()),
+	}, true
 }
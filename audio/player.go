@@ -1,19 +1,49 @@
 package audio
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // Player represents an audio playback engine that can play arbitrary audio URLs
 type Player interface {
 	io.Closer
 
 	// UpdateStream sets the target of the playback stream. If the stream
-	// is playing, it is automatically restarted with the new media source
-	UpdateStream(url string, volumeAdjustment float64)
+	// is playing, it is automatically restarted with the new media source.
+	// length is the expected length of the track, used to report progress
+	// before the whole track has been decoded. It may be 0 if unknown.
+	UpdateStream(url string, volumeAdjustment float64, length time.Duration)
+	// Preload starts fetching and decoding url in the background so it can
+	// start playing immediately if it is the next url passed to UpdateStream.
+	// Only one track is preloaded at a time, preloading a different url
+	// discards the previous one.
+	Preload(url string, volumeAdjustment float64, length time.Duration)
+	// CancelPreload discards the preloaded track, if any
+	CancelPreload()
 	// Play starts the playback stream
 	Play()
 	// Pause pauses the playback stream
 	Pause()
+	// Seek moves playback of the current track to position. If that part of
+	// the track hasn't been downloaded yet, playback resumes once it has.
+	Seek(position time.Duration)
+	// SeekBy moves playback of the current track by offset, which is negative
+	// to seek back
+	SeekBy(offset time.Duration)
+	// FadeOut quickly fades out the current track instead of cutting it off,
+	// for example when it is skipped. DoneChan is not notified.
+	FadeOut()
+	// SetVolume sets the master volume from 0 (silent) to MaxVolume percent.
+	// Values outside of that range are clamped.
+	SetVolume(percent int)
+	// SetMuted silences playback without changing the master volume
+	SetMuted(muted bool)
 
+	// Volume is the master volume in percent
+	Volume() int
+	// IsMuted is true if playback is muted
+	IsMuted() bool
 	// IsPlaying is true if the player is currently playing a track
 	IsPlaying() bool
 
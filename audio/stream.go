@@ -0,0 +1,248 @@
+package audio
+
+import (
+	"errors"
+	"sync"
+)
+
+const (
+	pcmChannels      = 2
+	pcmBytesPerFrame = pcmChannels * 2
+)
+
+var (
+	errStreamClosed    = errors.New("stream closed")
+	errSeekUnavailable = errors.New("seek position is no longer buffered")
+)
+
+// pcmStream is a beep.StreamSeekCloser that plays signed 16-bit little endian
+// stereo PCM as it is written to it. Decoded frames are held in a fixed-size
+// ring buffer: writes block while it is full of frames that haven't been played
+// yet and reads play silence if it runs dry before the writer has finished.
+// Frames are addressed by their position in the track, the most recently
+// played frames are kept around so they can be seeked back to.
+type pcmStream struct {
+	lock sync.Mutex
+	cond *sync.Cond
+
+	ring     [][2]int16
+	capacity int
+
+	// partial holds the bytes of a frame split across writes
+	partial []byte
+
+	length   int
+	decoded  int
+	position int
+
+	// oldest is the first frame that is still in the ring buffer
+	oldest int
+
+	finished bool
+	closed   bool
+	err      error
+}
+
+// newPCMStream creates a stream that buffers up to capacity frames ahead of
+// the current position and keeps up to history frames behind it. length is the
+// expected number of frames in the stream or 0 if it is unknown.
+func newPCMStream(capacity, history, length int) *pcmStream {
+	s := &pcmStream{
+		ring:     make([][2]int16, capacity+history),
+		capacity: capacity,
+		partial:  make([]byte, 0, pcmBytesPerFrame),
+		length:   length,
+	}
+
+	s.cond = sync.NewCond(&s.lock)
+	return s
+}
+
+// Write decodes p into frames, blocking until there is room for them in the
+// ring buffer. Frames before the current position are discarded, so seeking
+// ahead of the decoded audio skips to it as soon as it arrives.
+func (s *pcmStream) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := 0; i < len(p); i++ {
+		s.partial = append(s.partial, p[i])
+		if len(s.partial) < pcmBytesPerFrame {
+			continue
+		}
+
+		for s.decoded-s.position >= s.capacity && !s.closed {
+			s.cond.Wait()
+		}
+
+		if s.closed {
+			return i, errStreamClosed
+		}
+
+		if s.decoded < s.position {
+			s.oldest = s.decoded + 1
+		} else {
+			s.ring[s.decoded%len(s.ring)] = [2]int16{
+				int16(uint16(s.partial[0]) | uint16(s.partial[1])<<8),
+				int16(uint16(s.partial[2]) | uint16(s.partial[3])<<8),
+			}
+
+			if s.decoded-s.oldest >= len(s.ring) {
+				s.oldest = s.decoded - len(s.ring) + 1
+			}
+		}
+
+		s.decoded++
+		s.partial = s.partial[:0]
+		s.cond.Broadcast()
+	}
+
+	return len(p), nil
+}
+
+// finish marks the end of the stream. Once the buffer drains, Stream reports
+// that the stream is over and Err returns err.
+func (s *pcmStream) finish(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.finished {
+		return
+	}
+
+	s.finished = true
+	if !s.closed {
+		s.err = err
+	}
+
+	s.cond.Broadcast()
+}
+
+// waitBuffered blocks until at least n frames are buffered ahead of the
+// current position or no more frames are coming
+func (s *pcmStream) waitBuffered(n int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if n > s.capacity {
+		n = s.capacity
+	}
+
+	for s.decoded-s.position < n && !s.finished && !s.closed {
+		s.cond.Wait()
+	}
+}
+
+func (s *pcmStream) Stream(samples [][2]float64) (n int, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.position >= s.decoded && (s.finished || s.closed) {
+		return 0, false
+	}
+
+	for n < len(samples) && s.position < s.decoded {
+		frame := s.ring[s.position%len(s.ring)]
+		samples[n][0] = float64(frame[0]) / (1 << 15)
+		samples[n][1] = float64(frame[1]) / (1 << 15)
+
+		s.position++
+		n++
+	}
+
+	if n > 0 {
+		s.cond.Broadcast()
+	}
+
+	if s.finished {
+		return n, n > 0
+	}
+
+	// Buffer underrun, play silence until ffmpeg catches up
+	for ; n < len(samples); n++ {
+		samples[n] = [2]float64{}
+	}
+
+	return n, true
+}
+
+// Seek moves playback to frame p. Seeking ahead of the decoded audio plays
+// silence until ffmpeg catches up. Seeking back to a frame that is no longer
+// buffered fails with errSeekUnavailable.
+func (s *pcmStream) Seek(p int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if p < 0 {
+		p = 0
+	}
+
+	if p < s.oldest {
+		return errSeekUnavailable
+	}
+
+	if s.finished && p > s.decoded {
+		p = s.decoded
+	}
+
+	s.position = p
+	s.cond.Broadcast()
+
+	return nil
+}
+
+func (s *pcmStream) Err() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.err
+}
+
+// Len is the expected length of the stream from the track metadata until it
+// has been completely decoded, at which point it is the actual length
+func (s *pcmStream) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.finished || s.decoded > s.length {
+		return s.decoded
+	}
+
+	return s.length
+}
+
+// remaining returns how many frames are left to play. It is only known once the
+// stream has been completely decoded or if the expected length is known and
+// has not been exceeded yet.
+func (s *pcmStream) remaining() (int, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.finished {
+		return s.decoded - s.position, true
+	}
+
+	if s.length > 0 && s.decoded <= s.length {
+		return s.length - s.position, true
+	}
+
+	return 0, false
+}
+
+func (s *pcmStream) Position() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.position
+}
+
+// Close stops the stream, unblocking any pending writes
+func (s *pcmStream) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	s.cond.Broadcast()
+
+	return nil
+}
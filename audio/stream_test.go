@@ -0,0 +1,219 @@
+package audio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func frames(values ...int16) []byte {
+	var result []byte
+	for _, v := range values {
+		result = append(result, byte(uint16(v)), byte(uint16(v)>>8))
+	}
+
+	return result
+}
+
+func TestPCMStream_Decode(t *testing.T) {
+	sut := newPCMStream(4, 0, 0)
+
+	// Split a frame across writes
+	raw := frames(16384, -16384, 0, 32767)
+	_, err := sut.Write(raw[:3])
+	require.NoError(t, err)
+	_, err = sut.Write(raw[3:])
+	require.NoError(t, err)
+	sut.finish(nil)
+
+	samples := make([][2]float64, 4)
+	n, ok := sut.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 2, n)
+	require.Equal(t, [2]float64{0.5, -0.5}, samples[0])
+	require.Equal(t, 0.0, samples[1][0])
+	require.InDelta(t, 1.0, samples[1][1], 0.001)
+
+	n, ok = sut.Stream(samples)
+	require.False(t, ok)
+	require.Zero(t, n)
+	require.Equal(t, 2, sut.Position())
+	require.Equal(t, 2, sut.Len())
+}
+
+func TestPCMStream_Len(t *testing.T) {
+	sut := newPCMStream(4, 0, 100)
+	require.Equal(t, 100, sut.Len(), "Len should be the expected length until the stream is decoded")
+
+	_, err := sut.Write(frames(1, 1, 2, 2))
+	require.NoError(t, err)
+	sut.finish(nil)
+
+	require.Equal(t, 2, sut.Len(), "Len should be the decoded length once the stream is finished")
+}
+
+func TestPCMStream_Remaining(t *testing.T) {
+	sut := newPCMStream(4, 0, 3)
+
+	remaining, ok := sut.remaining()
+	require.True(t, ok)
+	require.Equal(t, 3, remaining)
+
+	_, err := sut.Write(frames(1, 1, 2, 2, 3, 3, 4, 4))
+	require.NoError(t, err)
+
+	_, ok = sut.remaining()
+	require.False(t, ok, "Remaining is unknown once the track is longer than expected")
+
+	sut.finish(nil)
+	remaining, ok = sut.remaining()
+	require.True(t, ok)
+	require.Equal(t, 4, remaining)
+}
+
+func TestPCMStream_Underrun(t *testing.T) {
+	sut := newPCMStream(4, 0, 0)
+
+	_, err := sut.Write(frames(16384, 16384))
+	require.NoError(t, err)
+
+	samples := make([][2]float64, 3)
+	n, ok := sut.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 3, n, "The stream should play silence while waiting for more audio")
+	require.Equal(t, [][2]float64{{0.5, 0.5}, {}, {}}, samples)
+	require.Equal(t, 1, sut.Position())
+}
+
+func TestPCMStream_Backpressure(t *testing.T) {
+	sut := newPCMStream(1, 0, 0)
+
+	_, err := sut.Write(frames(1, 1))
+	require.NoError(t, err)
+
+	written := make(chan struct{})
+	go func() {
+		_, _ = sut.Write(frames(2, 2))
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("Write should block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	samples := make([][2]float64, 1)
+	_, _ = sut.Stream(samples)
+
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for write to unblock")
+	}
+}
+
+func TestPCMStream_Close(t *testing.T) {
+	sut := newPCMStream(1, 0, 0)
+
+	_, err := sut.Write(frames(1, 1))
+	require.NoError(t, err)
+
+	errs := make(chan error)
+	go func() {
+		_, err := sut.Write(frames(2, 2))
+		errs <- err
+	}()
+
+	require.NoError(t, sut.Close())
+	require.True(t, errors.Is(<-errs, errStreamClosed))
+
+	sut.finish(errors.New("killed"))
+	require.NoError(t, sut.Err(), "Errors after the stream is closed should be ignored")
+}
+
+func TestPCMStream_Error(t *testing.T) {
+	sut := newPCMStream(1, 0, 0)
+	expected := errors.New("dummy")
+
+	sut.finish(expected)
+	sut.waitBuffered(1)
+
+	n, ok := sut.Stream(make([][2]float64, 1))
+	require.False(t, ok)
+	require.Zero(t, n)
+	require.Same(t, expected, sut.Err())
+}
+
+func TestPCMStream_Seek(t *testing.T) {
+	samples := make([][2]float64, 2)
+
+	t.Run("Back Within History", func(t *testing.T) {
+		sut := newPCMStream(2, 2, 0)
+
+		_, err := sut.Write(frames(1, 1, 2, 2))
+		require.NoError(t, err)
+		_, _ = sut.Stream(samples)
+
+		_, err = sut.Write(frames(3, 3, 4, 4))
+		require.NoError(t, err)
+
+		require.NoError(t, sut.Seek(1))
+		require.Equal(t, 1, sut.Position())
+
+		n, ok := sut.Stream(samples)
+		require.True(t, ok)
+		require.Equal(t, 2, n)
+		require.Equal(t, [][2]float64{{2.0 / (1 << 15), 2.0 / (1 << 15)}, {3.0 / (1 << 15), 3.0 / (1 << 15)}}, samples)
+	})
+
+	t.Run("Back Past History", func(t *testing.T) {
+		sut := newPCMStream(2, 0, 0)
+
+		_, err := sut.Write(frames(1, 1, 2, 2))
+		require.NoError(t, err)
+		_, _ = sut.Stream(samples)
+
+		_, err = sut.Write(frames(3, 3))
+		require.NoError(t, err)
+
+		require.True(t, errors.Is(sut.Seek(0), errSeekUnavailable))
+		require.Equal(t, 2, sut.Position())
+	})
+
+	t.Run("Ahead Of Decoded Audio", func(t *testing.T) {
+		sut := newPCMStream(2, 2, 0)
+
+		require.NoError(t, sut.Seek(2))
+		require.Equal(t, 2, sut.Position(), "Position should update before the audio arrives")
+
+		n, ok := sut.Stream(samples)
+		require.True(t, ok, "The stream should wait for audio")
+		require.Equal(t, 2, n)
+		require.Equal(t, [][2]float64{{}, {}}, samples)
+
+		_, err := sut.Write(frames(1, 1, 2, 2, 3, 3))
+		require.NoError(t, err)
+
+		_, ok = sut.Stream(samples)
+		require.True(t, ok)
+		require.Equal(t, 3.0/(1<<15), samples[0][0], "Frames before the seek position should be skipped")
+		require.True(t, errors.Is(sut.Seek(1), errSeekUnavailable), "Skipped frames should not be seekable")
+	})
+
+	t.Run("Past The End", func(t *testing.T) {
+		sut := newPCMStream(2, 2, 0)
+
+		_, err := sut.Write(frames(1, 1))
+		require.NoError(t, err)
+		sut.finish(nil)
+
+		require.NoError(t, sut.Seek(10))
+		require.Equal(t, 1, sut.Position())
+
+		_, ok := sut.Stream(samples)
+		require.False(t, ok)
+	})
+}
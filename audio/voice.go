@@ -0,0 +1,125 @@
+package audio
+
+import (
+	"github.com/faiface/beep/effects"
+)
+
+// voice is a track playing through the player's mixer. It applies the track's
+// replay gain and fades in or out over a fixed number of samples. Once a voice
+// has been added to the mixer, it may only be accessed with the speaker lock
+// held.
+type voice struct {
+	t      *transcoding
+	stream *pcmStream
+	volume *effects.Volume
+
+	gain float64
+	step float64
+
+	// progressed is called after each chunk of samples is streamed
+	progressed func(v *voice)
+	// ended is called once the track has played to completion. It is not
+	// called if the voice was faded out.
+	ended func(v *voice)
+
+	stopped bool
+}
+
+func newVoice(t *transcoding, stream *pcmStream, volumeAdjustment float64) *voice {
+	return &voice{
+		t:      t,
+		stream: stream,
+		volume: &effects.Volume{
+			Base:     10,
+			Volume:   volumeAdjustment / 10,
+			Streamer: stream,
+		},
+
+		gain: 1,
+	}
+}
+
+// replace switches to playing stream, stopping the old transcoding
+func (v *voice) replace(t *transcoding, stream *pcmStream) {
+	old := v.t
+
+	v.t = t
+	v.stream = stream
+	v.volume.Streamer = stream
+
+	old.Close()
+}
+
+// fadeIn starts the voice silent and ramps it up to full volume over n samples
+func (v *voice) fadeIn(n int) {
+	if n <= 0 {
+		v.gain, v.step = 1, 0
+		return
+	}
+
+	v.gain, v.step = 0, 1/float64(n)
+}
+
+// fadeOut ramps the voice down over n samples, after which it stops
+func (v *voice) fadeOut(n int) {
+	if n <= 0 {
+		v.stop()
+		return
+	}
+
+	v.step = -v.gain / float64(n)
+}
+
+// fadingOut is true if the voice is on its way out
+func (v *voice) fadingOut() bool {
+	return v.stopped || v.step < 0
+}
+
+// stop silences the voice immediately and stops transcoding the track
+func (v *voice) stop() {
+	if !v.stopped {
+		v.stopped = true
+		v.t.Close()
+	}
+}
+
+func (v *voice) Stream(samples [][2]float64) (n int, ok bool) {
+	if v.stopped {
+		return 0, false
+	}
+
+	n, ok = v.volume.Stream(samples)
+	for i := 0; i < n; i++ {
+		if v.step != 0 {
+			v.gain += v.step
+
+			if v.gain >= 1 {
+				v.gain, v.step = 1, 0
+			} else if v.gain <= 0 {
+				v.stop()
+				return i, i > 0
+			}
+		}
+
+		samples[i][0] *= v.gain
+		samples[i][1] *= v.gain
+	}
+
+	if v.progressed != nil {
+		v.progressed(v)
+	}
+
+	if !ok && !v.stopped {
+		v.stop()
+
+		if v.ended != nil {
+			v.ended(v)
+		}
+	}
+
+	return n, ok
+}
+
+func (v *voice) Err() error {
+	return v.stream.Err()
+}
@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// makeVoice creates a voice playing n frames at half volume
+func makeVoice(t *testing.T, n int) *voice {
+	stream := newPCMStream(n, 0, n)
+
+	var raw []byte
+	for i := 0; i < n; i++ {
+		raw = append(raw, frames(16384, 16384)...)
+	}
+
+	_, err := stream.Write(raw)
+	require.NoError(t, err)
+	stream.finish(nil)
+
+	ready := make(chan struct{})
+	close(ready)
+
+	return newVoice(&transcoding{stop: func() {}, ready: ready, stream: stream}, stream, 0)
+}
+
+func TestVoice_FadeIn(t *testing.T) {
+	sut := makeVoice(t, 4)
+	sut.fadeIn(2)
+
+	samples := make([][2]float64, 4)
+	n, ok := sut.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 4, n)
+	require.Equal(t, [][2]float64{{0.25, 0.25}, {0.5, 0.5}, {0.5, 0.5}, {0.5, 0.5}}, samples)
+}
+
+func TestVoice_FadeOut(t *testing.T) {
+	sut := makeVoice(t, 4)
+
+	ended := false
+	sut.ended = func(_ *voice) {
+		ended = true
+	}
+
+	sut.fadeOut(2)
+	require.True(t, sut.fadingOut())
+
+	samples := make([][2]float64, 4)
+	n, ok := sut.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 1, n, "The voice should stop once it is silent")
+	require.Equal(t, [2]float64{0.25, 0.25}, samples[0])
+
+	n, ok = sut.Stream(samples)
+	require.False(t, ok)
+	require.Zero(t, n)
+	require.False(t, ended, "Voices that were faded out should not end")
+	require.Equal(t, errStreamClosed, func() error {
+		_, err := sut.stream.Write(frames(1, 1))
+		return err
+	}())
+}
+
+func TestVoice_Ended(t *testing.T) {
+	sut := makeVoice(t, 2)
+
+	var progressed, ended int
+	sut.progressed = func(v *voice) {
+		require.Same(t, sut, v)
+		progressed++
+	}
+	sut.ended = func(v *voice) {
+		require.Same(t, sut, v)
+		ended++
+	}
+
+	samples := make([][2]float64, 4)
+	n, ok := sut.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 2, n)
+	require.Zero(t, ended)
+
+	n, ok = sut.Stream(samples)
+	require.False(t, ok)
+	require.Zero(t, n)
+	require.Equal(t, 1, ended)
+
+	_, _ = sut.Stream(samples)
+	require.Equal(t, 1, ended, "ended should only be called once")
+	require.Equal(t, 2, progressed)
+}
@@ -0,0 +1,35 @@
+package audio
+
+import (
+	"github.com/faiface/beep/effects"
+)
+
+const (
+	// MaxVolume is the loudest master volume, it plays tracks unattenuated
+	MaxVolume = 100
+
+	// volumeRange is how many decibels tracks are attenuated by at the lowest
+	// non-zero volume
+	volumeRange = 40.0
+)
+
+// clampVolume limits percent to between 0 and MaxVolume
+func clampVolume(percent int) int {
+	if percent < 0 {
+		return 0
+	} else if percent > MaxVolume {
+		return MaxVolume
+	}
+
+	return percent
+}
+
+// applyVolume configures v to play at percent of full volume. Percentages are
+// mapped linearly to decibels so each step sounds about as loud as the last,
+// 0% and muted are silent.
+func applyVolume(v *effects.Volume, percent int, muted bool) {
+	percent = clampVolume(percent)
+
+	v.Silent = muted || percent == 0
+	v.Volume = (float64(percent)/MaxVolume - 1) * volumeRange / 20
+}
@@ -0,0 +1,40 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClampVolume(t *testing.T) {
+	require.Equal(t, 0, clampVolume(-5))
+	require.Equal(t, 50, clampVolume(50))
+	require.Equal(t, MaxVolume, clampVolume(120))
+}
+
+func TestApplyVolume(t *testing.T) {
+	play := func(percent int, muted bool) float64 {
+		v := &effects.Volume{
+			Base: 10,
+			Streamer: beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+				for i := range samples {
+					samples[i] = [2]float64{1, 1}
+				}
+				return len(samples), true
+			}),
+		}
+		applyVolume(v, percent, muted)
+
+		samples := make([][2]float64, 1)
+		_, _ = v.Stream(samples)
+		return samples[0][0]
+	}
+
+	require.Equal(t, 1.0, play(MaxVolume, false), "Full volume should not attenuate tracks")
+	require.InDelta(t, 0.1, play(50, false), 0.0001, "Half volume should be 20dB quieter")
+	require.InDelta(t, 0.0105, play(1, false), 0.0001)
+	require.Zero(t, play(0, false), "0% should be silent")
+	require.Zero(t, play(MaxVolume, true), "Muted should be silent")
+}
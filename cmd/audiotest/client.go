@@ -18,7 +18,7 @@ var clientCmd = &cobra.Command{
 	Example: "mousiki audiotest client http://localhost:5000/stream",
 	Args:    cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
-		player, err := audio.NewBeepFFmpegPipeline()
+		player, err := audio.NewBeepFFmpegPipeline(audio.WithPrebuffer(viper.GetDuration("prebuffer")))
 		if err != nil {
 			return err
 		}
@@ -34,7 +34,7 @@ var clientCmd = &cobra.Command{
 			}
 		}()
 
-		player.UpdateStream(args[0], viper.GetFloat64("gain"))
+		player.UpdateStream(args[0], viper.GetFloat64("gain"), viper.GetDuration("length"))
 
 		if err := keyboard.Open(); err != nil {
 			return err
@@ -74,6 +74,7 @@ func init() {
 	flags := clientCmd.PersistentFlags()
 
 	flags.Float64P("gain", "g", 0.0, "Relative File Gain (in dB) to apply")
+	flags.Duration("length", 0, "Expected length of the track, used to report progress while it streams")
 
 	_ = viper.BindPFlags(flags)
 
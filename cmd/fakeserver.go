@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora/fake"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var fakeServerCmd = &cobra.Command{
+	Use:     "fakeserver",
+	Short:   "Run a fake pandora server",
+	Long:    "Serve a fake pandora for offline development. It accepts the username and password passed to it.",
+	Example: "mousiki fakeserver -u somebody -p hunter22",
+	Args:    cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		un := viper.GetString("username")
+		pw := viper.GetString("password")
+		if pw == "" {
+			return errors.New("fakeserver: a --password is required")
+		}
+
+		log := logrus.WithField("prefix", "fakeserver")
+		server := fake.New(un, pw,
+			fake.WithTokenTTL(viper.GetDuration("token-ttl")),
+			fake.WithSkipLimit(viper.GetInt("skip-limit"), time.Hour),
+			fake.WithAudioLength(viper.GetDuration("audio-length")),
+		)
+
+		for i := 1; i <= viper.GetInt("stations"); i++ {
+			server.AddStation(fmt.Sprintf("Fake Station %d Radio", i))
+		}
+
+		listen := viper.GetString("listen")
+		s := &http.Server{
+			Addr:    listen,
+			Handler: server.Handler(),
+		}
+
+		go func() {
+			log.WithField("listen", listen).Info("Serving fake pandora")
+			log.Infof("Connect with: mousiki -u %s --pandora-url http://%s --legacy-url http://%s%s", un, listen, listen, fake.LegacyPath)
+			if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.WithError(err).Fatal("Error serving requests")
+			}
+		}()
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+
+		<-c
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		log.Info("Shutting Down")
+		return s.Shutdown(ctx)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(fakeServerCmd)
+
+	flags := fakeServerCmd.Flags()
+	flags.String("listen", "127.0.0.1:5100", "Address to serve the fake pandora on")
+	flags.Int("stations", 5, "Number of stations to create")
+	flags.Duration("token-ttl", time.Hour, "How long auth tokens are valid for")
+	flags.Int("skip-limit", 6, "How many tracks can be skipped per station per hour")
+	flags.Duration("audio-length", 30*time.Second, "Length of the generated audio for each track")
+	_ = viper.BindPFlags(flags)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/cheggaaa/pb/v3"
 	"github.com/mattn/go-colorable"
@@ -30,32 +32,31 @@ var RootCmd = &cobra.Command{
 	Long:  "A command-line pandora client based off of pianobar",
 	Args:  cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
-		p := api.NewClient()
-
-		un := viper.GetString("username")
-		pw := viper.GetString("password")
-
-		if pw == "" {
-			fmt.Print("Password: ")
-			raw, _ := terminal.ReadPassword(int(os.Stdin.Fd()))
-			pw = string(raw)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-			if len(pw) < 8 {
-				return fmt.Errorf("got bad password: %s (hex: %s)", pw, hex.EncodeToString(raw))
-			}
+		p, err := newClient()
+		if err != nil {
+			return err
+		}
 
-			fmt.Println()
+		if err := login(ctx, p); err != nil {
+			return err
 		}
 
-		if pw == "" {
-			logrus.Fatal("No password provided")
+		playerOpts := []audio.Option{
+			audio.WithPrebuffer(viper.GetDuration("prebuffer")),
+			audio.WithCrossfade(viper.GetDuration("crossfade")),
 		}
 
-		if err := p.LegacyLogin(un, pw); err != nil {
+		// Download tracks through the same proxy as everything else
+		if rt, err := proxyTransport(); err != nil {
 			return err
+		} else if rt != nil {
+			playerOpts = append(playerOpts, audio.WithTransport(rt))
 		}
 
-		player, err := audio.NewBeepFFmpegPipeline()
+		player, err := audio.NewBeepFFmpegPipeline(playerOpts...)
 		if err != nil {
 			return err
 		}
@@ -64,16 +65,179 @@ var RootCmd = &cobra.Command{
 			_ = player.Close()
 		}()
 
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+		settingsPath, err := mousiki.DefaultSettingsPath()
+		if err != nil {
+			logrus.WithError(err).Warn("Could not locate config dir, settings will not be saved")
+		} else {
+			settings, err := mousiki.LoadSettings(settingsPath)
+			if err != nil {
+				logrus.WithError(err).Debug("No saved settings")
+			}
 
-		controller := mousiki.NewStationController(p, player)
+			player.SetVolume(settings.Volume)
+			player.SetMuted(settings.Muted)
+
+			defer func() {
+				settings := mousiki.Settings{Volume: player.Volume(), Muted: player.IsMuted()}
+				if err := mousiki.SaveSettings(settingsPath, settings); err != nil {
+					logrus.WithError(err).Warn("Failed to save settings")
+				}
+			}()
+		}
+
+		controller := mousiki.NewStationController(p, p, player)
+		if path, err := mousiki.DefaultGenreCachePath(viper.GetString("api")); err != nil {
+			logrus.WithError(err).Warn("Could not locate cache dir, genre stations will not be cached")
+		} else {
+			controller.CacheGenresAt(path)
+		}
 
 		app := ui.New(ctx, cancel, player, controller)
 		return app.Run()
 	},
 }
 
+type pandoraClient interface {
+	api.Client
+	api.TrackReporter
+	sessionClient
+}
+
+// newClient creates a pandora client for the API selected with --api
+func newClient() (pandoraClient, error) {
+	opts, err := clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind := viper.GetString("api"); kind {
+	case "rest":
+		return api.NewClient(opts...), nil
+	case "legacy":
+		return api.NewLegacyClient(opts...), nil
+	default:
+		return nil, fmt.Errorf("unknown api %q, expected one of [rest, legacy]", kind)
+	}
+}
+
+// clientOptions configures pandora clients from the endpoint, proxy, and user
+// agent settings
+func clientOptions() ([]api.Option, error) {
+	var opts []api.Option
+
+	if base := viper.GetString("pandora-url"); base != "" {
+		opts = append(opts, api.WithBaseURL(base))
+	}
+
+	if endpoint := viper.GetString("legacy-url"); endpoint != "" {
+		opts = append(opts, api.WithLegacyURL(endpoint))
+	}
+
+	rt, err := proxyTransport()
+	if err != nil {
+		return nil, err
+	} else if rt != nil {
+		opts = append(opts, api.WithTransport(rt))
+	}
+
+	if ua := viper.GetString("user-agent"); ua != "" {
+		opts = append(opts, api.WithUserAgent(ua))
+	}
+
+	return opts, nil
+}
+
+// proxyTransport returns a transport for the proxy set with --proxy, or nil if
+// the default transport should be used
+func proxyTransport() (http.RoundTripper, error) {
+	proxy := viper.GetString("proxy")
+	if proxy == "" {
+		return nil, nil
+	}
+
+	return api.ProxyTransport(proxy)
+}
+
+type sessionClient interface {
+	LegacyLogin(ctx context.Context, username, password string) error
+	ResumeSession(ctx context.Context, s api.Session) error
+	Session() (api.Session, error)
+}
+
+// login tries to resume a previously saved session for the configured user,
+// falling back to logging in with a password if there is no saved session or
+// pandora no longer accepts it.
+func login(ctx context.Context, p sessionClient) error {
+	un := viper.GetString("username")
+	remember := viper.GetBool("remember")
+
+	sessionPath, err := mousiki.DefaultSessionPath()
+	if err != nil {
+		logrus.WithError(err).Warn("Could not locate session store, sessions will not be saved")
+		remember = false
+	}
+
+	if remember {
+		if s, err := mousiki.LoadSession(sessionPath); err != nil {
+			logrus.WithError(err).Debug("No saved session")
+		} else if s.Username != un {
+			logrus.WithField("username", s.Username).Debug("Saved session belongs to a different user")
+		} else if err := p.ResumeSession(ctx, s); err != nil {
+			logrus.WithError(err).Warn("Saved session is no longer valid")
+		} else {
+			// Pandora may hand out a new auth token when resuming, so save
+			// the session again to keep the stored token fresh
+			saveSession(p, sessionPath)
+			return nil
+		}
+	}
+
+	pw := viper.GetString("password")
+	if pw == "" {
+		fmt.Print("Password: ")
+		raw, _ := terminal.ReadPassword(int(os.Stdin.Fd()))
+		pw = string(raw)
+
+		if len(pw) < 8 {
+			return fmt.Errorf("got bad password: %s (hex: %s)", pw, hex.EncodeToString(raw))
+		}
+
+		fmt.Println()
+	}
+
+	if pw == "" {
+		logrus.Fatal("No password provided")
+	}
+
+	if err := p.LegacyLogin(ctx, un, pw); err != nil {
+		return err
+	}
+
+	if !remember {
+		if sessionPath != "" {
+			return mousiki.ClearSession(sessionPath)
+		}
+
+		return nil
+	}
+
+	saveSession(p, sessionPath)
+	return nil
+}
+
+// saveSession stores the current session so it can be resumed the next time
+// mousiki starts. We're already logged in, so failing to save it isn't fatal.
+func saveSession(p sessionClient, sessionPath string) {
+	s, err := p.Session()
+	if err == nil {
+		err = mousiki.SaveSession(sessionPath, s)
+	}
+
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to save session")
+	}
+}
+
 func MarkFlagRequired(cmd *cobra.Command, name string) {
 	_ = cmd.MarkFlagRequired(name)
 }
@@ -107,8 +271,17 @@ func init() {
 	flags.StringP("username", "u", "", "Pandora Username")
 	MarkFlagRequired(RootCmd, "username")
 	flags.StringP("password", "p", "", "Pandora Password")
+	flags.Bool("remember", true, "Save the login session and reuse it on the next launch")
 
+	flags.String("api", "rest", "Pandora API to use [rest, legacy]")
 	flags.StringP("audio-format", "a", string(pandora.AudioFormatAACPlus), "Audio Format to use [aacplus, mp3]")
+	flags.Duration("prebuffer", 500*time.Millisecond, "How much audio to decode before starting each track")
+	flags.Duration("crossfade", 0, "Fade each track into the next over this long, 0 to play tracks back to back")
+	flags.Duration("request-timeout", 30*time.Second, "Timeout for individual requests to pandora, 0 to disable")
+	flags.String("pandora-url", "", "Base URL of the pandora REST API (default https://www.pandora.com)")
+	flags.String("legacy-url", "", "Endpoint of the pandora legacy JSON API (default https://tuner.pandora.com/services/json/)")
+	flags.String("proxy", "", "Send requests to pandora through this http, https, or socks5 proxy (default from HTTPS_PROXY)")
+	flags.String("user-agent", "", "User-Agent to send to pandora")
 
 	flags.StringP("verbosity", "v", "info", "Verbosity []")
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -16,7 +17,12 @@ var tokenTestCmd = &cobra.Command{
 	Hidden: true,
 	Args:   cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
-		p := api.NewClient()
+		opts, err := clientOptions()
+		if err != nil {
+			return err
+		}
+
+		p := api.NewClient(opts...)
 
 		un := viper.GetString("username")
 		pw := viper.GetString("password")
@@ -32,11 +38,11 @@ var tokenTestCmd = &cobra.Command{
 			logrus.Fatal("No password provided")
 		}
 
-		if err := p.LegacyLogin(un, pw); err != nil {
+		if err := p.LegacyLogin(context.Background(), un, pw); err != nil {
 			return err
 		}
 
-		stations, err := p.GetStations()
+		stations, err := p.GetStations(context.Background())
 		if err != nil {
 			return err
 		}
@@ -48,15 +48,64 @@ var uiTestCmd = &cobra.Command{
 		player.On("Play").Run(func(_ mock.Arguments) {
 			playing = true
 		}).Return()
-		player.On("UpdateStream", mock.Anything, mock.Anything).Return()
+		player.On("UpdateStream", mock.Anything, mock.Anything, mock.Anything).Return()
+		player.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+		player.On("CancelPreload").Return()
+		player.On("FadeOut").Return()
+		seek := func(position time.Duration) {
+			if position < 0 {
+				position = 0
+			} else if position > progressData.Duration {
+				position = progressData.Duration
+			}
+
+			progressData.Progress = position
+			select {
+			case testProgress <- progressData:
+			default:
+			}
+		}
+		player.On("Seek", mock.Anything).Run(func(args mock.Arguments) {
+			seek(args.Get(0).(time.Duration))
+		}).Return()
+		player.On("SeekBy", mock.Anything).Run(func(args mock.Arguments) {
+			seek(progressData.Progress + args.Get(0).(time.Duration))
+		}).Return()
+
+		volume, muted := audio.MaxVolume, false
+		player.On("Volume").Return(func() int {
+			return volume
+		})
+		player.On("IsMuted").Return(func() bool {
+			return muted
+		})
+		player.On("SetVolume", mock.Anything).Run(func(args mock.Arguments) {
+			volume = args.Int(0)
+			if volume < 0 {
+				volume = 0
+			} else if volume > audio.MaxVolume {
+				volume = audio.MaxVolume
+			}
+		}).Return()
+		player.On("SetMuted", mock.Anything).Run(func(args mock.Arguments) {
+			muted = args.Bool(0)
+		}).Return()
 
 		ctx, cancel := context.WithCancel(context.TODO())
 
-		app := ui.New(ctx, cancel, player, mousiki.NewStationController(testDataAPI(), player))
+		app := ui.New(ctx, cancel, player, mousiki.NewStationController(testDataAPI(), testReporter(), player))
 		return app.Run()
 	},
 }
 
+func testReporter() api.TrackReporter {
+	reporter := &mocks.TrackReporter{}
+	reporter.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
+	reporter.On("ReportSkip", mock.Anything, mock.Anything).Return(nil)
+
+	return reporter
+}
+
 func testDataAPI() api.Client {
 	client := &mocks.Client{}
 
@@ -70,22 +119,104 @@ func testDataAPI() api.Client {
 		})
 	}
 
-	client.On("GetStations").Return(testStations, nil)
-	client.On("GetMoreTracks", mock.Anything).Return(func(_ string) []pandora.Track {
-		return []pandora.Track{
+	client.On("GetStations", mock.Anything).Return(testStations, nil)
+	client.On("StreamStations", mock.Anything, mock.Anything).Return(func(_ context.Context, f func([]pandora.Station) error) error {
+		return f(testStations)
+	})
+	client.On("CreateStation", mock.Anything, mock.Anything).Return(func(_ context.Context, pandoraId string) pandora.Station {
+		return pandora.Station{
+			ID:   uuid.Must(uuid.NewRandom()).String(),
+			Name: fmt.Sprintf("%s Radio", pandoraId),
+		}
+	}, nil)
+	client.On("RenameStation", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	client.On("DeleteStation", mock.Anything, mock.Anything).Return(nil)
+	client.On("Search", mock.Anything, mock.Anything).Return(func(_ context.Context, query string) pandora.SearchResults {
+		return pandora.SearchResults{
+			Artists: []pandora.SearchResult{
+				{PandoraId: "AR:1", Type: pandora.SearchResultTypeArtist, Name: fmt.Sprintf("%s Artist", query)},
+			},
+			Tracks: []pandora.SearchResult{
+				{PandoraId: "TR:1", Type: pandora.SearchResultTypeTrack, Name: fmt.Sprintf("%s Song", query), ArtistName: "Test Artist"},
+			},
+			Genres: []pandora.SearchResult{
+				{PandoraId: "SF:1", Type: pandora.SearchResultTypeGenre, Name: fmt.Sprintf("%s Genre", query)},
+			},
+		}
+	}, nil)
+	client.On("GetStationDetails", mock.Anything, mock.Anything).Return(func(_ context.Context, stationId string) pandora.StationDetails {
+		seed := pandora.StationSeed{SeedId: "1", PandoraId: "AR:1", Type: pandora.SearchResultTypeArtist, Name: "Test Artist"}
+
+		return pandora.StationDetails{
+			Station:     pandora.Station{ID: stationId},
+			InitialSeed: seed,
+			Seeds: []pandora.StationSeed{
+				seed,
+				{SeedId: "2", PandoraId: "TR:1", Type: pandora.SearchResultTypeTrack, Name: "Test Song", ArtistName: "Test Artist"},
+			},
+			PositiveFeedbackCount: 42,
+			NegativeFeedbackCount: 7,
+		}
+	}, nil)
+	client.On("AddSeed", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _, pandoraId string) pandora.StationSeed {
+		return pandora.StationSeed{
+			SeedId:    uuid.Must(uuid.NewRandom()).String(),
+			PandoraId: pandoraId,
+			Type:      pandora.SearchResultTypeArtist,
+			Name:      pandoraId,
+		}
+	}, nil)
+	client.On("RemoveSeed", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	client.On("RestartStation", mock.Anything).Return()
+	client.On("GetMoreTracks", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, stationId string, _ api.FragmentRequestReason) pandora.Fragment {
+		return pandora.Fragment{Tracks: []pandora.Track{
 			{
-				StationId:  uuid.Must(uuid.NewRandom()).String(),
-				MusicId:    uuid.Must(uuid.NewRandom()).String(),
-				TrackToken: uuid.Must(uuid.NewRandom()).String(),
-				ArtistName: "Test Artist",
-				AlbumTitle: "Test Album",
-				SongTitle:  fmt.Sprintf("Test Track %s", uuid.Must(uuid.NewRandom())),
+				StationId:     stationId,
+				MusicId:       uuid.Must(uuid.NewRandom()).String(),
+				PandoraId:     fmt.Sprintf("TR:%s", uuid.Must(uuid.NewRandom())),
+				ArtistMusicId: "AR:1",
+				TrackToken:    uuid.Must(uuid.NewRandom()).String(),
+				ArtistName:    "Test Artist",
+				AlbumTitle:    "Test Album",
+				SongTitle:     fmt.Sprintf("Test Track %s", uuid.Must(uuid.NewRandom())),
+
+				AllowStartStationFromTrack: true,
+				AllowSkip:                  true,
 			},
+		}}
+	}, nil)
+	client.On("GetGenreCategories", mock.Anything).Return([]pandora.GenreCategory{
+		{Name: "Rock", Stations: []pandora.GenreStation{{PandoraId: "G1", Name: "Classic Rock"}, {PandoraId: "G2", Name: "Indie Rock"}}},
+		{Name: "Jazz", Stations: []pandora.GenreStation{{PandoraId: "G3", Name: "Smooth Jazz"}}},
+	}, nil)
+	client.On("BookmarkSong", mock.Anything, mock.Anything).Return(pandora.Bookmark{}, nil)
+	client.On("BookmarkArtist", mock.Anything, mock.Anything).Return(pandora.Bookmark{}, nil)
+	client.On("GetBookmarks", mock.Anything).Return(pandora.Bookmarks{
+		Artists: []pandora.Bookmark{{ID: "1", Type: pandora.BookmarkTypeArtist, PandoraId: "AR:1", ArtistName: "Test Artist"}},
+		Songs:   []pandora.Bookmark{{ID: "2", Type: pandora.BookmarkTypeSong, PandoraId: "TR:1", SongTitle: "Test Track", ArtistName: "Test Artist"}},
+	}, nil)
+	client.On("AddFeedback", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ string, isPositive bool) pandora.Feedback {
+		return pandora.Feedback{
+			ID:         uuid.Must(uuid.NewRandom()).String(),
+			IsPositive: isPositive,
 		}
 	}, nil)
-	client.On("AddFeedback", mock.Anything, mock.Anything).Return(nil)
-	client.On("AddTired", mock.Anything).Return(nil)
-	client.On("GetNarrative", mock.Anything, mock.Anything).Return(pandora.Narrative{
+	client.On("GetStationFeedback", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ string, f func([]pandora.Feedback) error) error {
+		var feedback []pandora.Feedback
+		for i := 0; i < 10; i++ {
+			feedback = append(feedback, pandora.Feedback{
+				ID:         uuid.Must(uuid.NewRandom()).String(),
+				IsPositive: i%3 != 0,
+				SongTitle:  fmt.Sprintf("Test Track %d", i),
+				ArtistName: "Test Artist",
+			})
+		}
+
+		return f(feedback)
+	})
+	client.On("DeleteFeedback", mock.Anything, mock.Anything).Return(nil)
+	client.On("AddTired", mock.Anything, mock.Anything).Return(nil)
+	client.On("GetNarrative", mock.Anything, mock.Anything, mock.Anything).Return(pandora.Narrative{
 		Intro: "Based on what you've told us so far, we're playing this track because it features:",
 		FocusTraits: []string{
 			"vocal harmonies",
@@ -29,7 +29,6 @@ require (
 	github.com/stretchr/testify v1.5.1
 	github.com/x-cray/logrus-prefixed-formatter v0.5.2
 	gitlab.com/tslocum/cview v1.4.7-0.20200524163617-eafc5b33a249
-	go.uber.org/multierr v1.6.0
 	golang.org/x/crypto v0.0.0-20200406173513-056763e48d71
 	golang.org/x/sys v0.0.0-20200824131525-c12d262b63d8 // indirect
 	gopkg.in/ini.v1 v1.55.0 // indirect
@@ -3,7 +3,9 @@
 package mocks
 
 import (
+	context "context"
 	pandora "github.com/nlowe/mousiki/pandora"
+	api "github.com/nlowe/mousiki/pandora/api"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,13 +14,132 @@ type Client struct {
 	mock.Mock
 }
 
-// AddFeedback provides a mock function with given fields: trackToken, isPositive
-func (_m *Client) AddFeedback(trackToken string, isPositive bool) error {
-	ret := _m.Called(trackToken, isPositive)
+// AddFeedback provides a mock function with given fields: ctx, trackToken, isPositive
+func (_m *Client) AddFeedback(ctx context.Context, trackToken string, isPositive bool) (pandora.Feedback, error) {
+	ret := _m.Called(ctx, trackToken, isPositive)
+
+	var r0 pandora.Feedback
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) pandora.Feedback); ok {
+		r0 = rf(ctx, trackToken, isPositive)
+	} else {
+		r0 = ret.Get(0).(pandora.Feedback)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, trackToken, isPositive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddSeed provides a mock function with given fields: ctx, stationId, pandoraId
+func (_m *Client) AddSeed(ctx context.Context, stationId string, pandoraId string) (pandora.StationSeed, error) {
+	ret := _m.Called(ctx, stationId, pandoraId)
+
+	var r0 pandora.StationSeed
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) pandora.StationSeed); ok {
+		r0 = rf(ctx, stationId, pandoraId)
+	} else {
+		r0 = ret.Get(0).(pandora.StationSeed)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stationId, pandoraId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddTired provides a mock function with given fields: ctx, trackToken
+func (_m *Client) AddTired(ctx context.Context, trackToken string) error {
+	ret := _m.Called(ctx, trackToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, trackToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkArtist provides a mock function with given fields: ctx, trackToken
+func (_m *Client) BookmarkArtist(ctx context.Context, trackToken string) (pandora.Bookmark, error) {
+	ret := _m.Called(ctx, trackToken)
+
+	var r0 pandora.Bookmark
+	if rf, ok := ret.Get(0).(func(context.Context, string) pandora.Bookmark); ok {
+		r0 = rf(ctx, trackToken)
+	} else {
+		r0 = ret.Get(0).(pandora.Bookmark)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trackToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkSong provides a mock function with given fields: ctx, trackToken
+func (_m *Client) BookmarkSong(ctx context.Context, trackToken string) (pandora.Bookmark, error) {
+	ret := _m.Called(ctx, trackToken)
+
+	var r0 pandora.Bookmark
+	if rf, ok := ret.Get(0).(func(context.Context, string) pandora.Bookmark); ok {
+		r0 = rf(ctx, trackToken)
+	} else {
+		r0 = ret.Get(0).(pandora.Bookmark)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trackToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStation provides a mock function with given fields: ctx, pandoraId
+func (_m *Client) CreateStation(ctx context.Context, pandoraId string) (pandora.Station, error) {
+	ret := _m.Called(ctx, pandoraId)
+
+	var r0 pandora.Station
+	if rf, ok := ret.Get(0).(func(context.Context, string) pandora.Station); ok {
+		r0 = rf(ctx, pandoraId)
+	} else {
+		r0 = ret.Get(0).(pandora.Station)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pandoraId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFeedback provides a mock function with given fields: ctx, feedbackId
+func (_m *Client) DeleteFeedback(ctx context.Context, feedbackId string) error {
+	ret := _m.Called(ctx, feedbackId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
-		r0 = rf(trackToken, isPositive)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, feedbackId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +147,13 @@ func (_m *Client) AddFeedback(trackToken string, isPositive bool) error {
 	return r0
 }
 
-// AddTired provides a mock function with given fields: trackToken
-func (_m *Client) AddTired(trackToken string) error {
-	ret := _m.Called(trackToken)
+// DeleteStation provides a mock function with given fields: ctx, stationId
+func (_m *Client) DeleteStation(ctx context.Context, stationId string) error {
+	ret := _m.Called(ctx, stationId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(trackToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, stationId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,22 +161,64 @@ func (_m *Client) AddTired(trackToken string) error {
 	return r0
 }
 
-// GetMoreTracks provides a mock function with given fields: stationId
-func (_m *Client) GetMoreTracks(stationId string) ([]pandora.Track, error) {
-	ret := _m.Called(stationId)
+// GetBookmarks provides a mock function with given fields: ctx
+func (_m *Client) GetBookmarks(ctx context.Context) (pandora.Bookmarks, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []pandora.Track
-	if rf, ok := ret.Get(0).(func(string) []pandora.Track); ok {
-		r0 = rf(stationId)
+	var r0 pandora.Bookmarks
+	if rf, ok := ret.Get(0).(func(context.Context) pandora.Bookmarks); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(pandora.Bookmarks)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGenreCategories provides a mock function with given fields: ctx
+func (_m *Client) GetGenreCategories(ctx context.Context) ([]pandora.GenreCategory, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []pandora.GenreCategory
+	if rf, ok := ret.Get(0).(func(context.Context) []pandora.GenreCategory); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]pandora.Track)
+			r0 = ret.Get(0).([]pandora.GenreCategory)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(stationId)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMoreTracks provides a mock function with given fields: ctx, stationId, reason
+func (_m *Client) GetMoreTracks(ctx context.Context, stationId string, reason api.FragmentRequestReason) (pandora.Fragment, error) {
+	ret := _m.Called(ctx, stationId, reason)
+
+	var r0 pandora.Fragment
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.FragmentRequestReason) pandora.Fragment); ok {
+		r0 = rf(ctx, stationId, reason)
+	} else {
+		r0 = ret.Get(0).(pandora.Fragment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.FragmentRequestReason) error); ok {
+		r1 = rf(ctx, stationId, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,20 +226,20 @@ func (_m *Client) GetMoreTracks(stationId string) ([]pandora.Track, error) {
 	return r0, r1
 }
 
-// GetNarrative provides a mock function with given fields: stationId, musicId
-func (_m *Client) GetNarrative(stationId string, musicId string) (pandora.Narrative, error) {
-	ret := _m.Called(stationId, musicId)
+// GetNarrative provides a mock function with given fields: ctx, stationId, musicId
+func (_m *Client) GetNarrative(ctx context.Context, stationId string, musicId string) (pandora.Narrative, error) {
+	ret := _m.Called(ctx, stationId, musicId)
 
 	var r0 pandora.Narrative
-	if rf, ok := ret.Get(0).(func(string, string) pandora.Narrative); ok {
-		r0 = rf(stationId, musicId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) pandora.Narrative); ok {
+		r0 = rf(ctx, stationId, musicId)
 	} else {
 		r0 = ret.Get(0).(pandora.Narrative)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(stationId, musicId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stationId, musicId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,13 +247,48 @@ func (_m *Client) GetNarrative(stationId string, musicId string) (pandora.Narrat
 	return r0, r1
 }
 
-// GetStations provides a mock function with given fields:
-func (_m *Client) GetStations() ([]pandora.Station, error) {
-	ret := _m.Called()
+// GetStationDetails provides a mock function with given fields: ctx, stationId
+func (_m *Client) GetStationDetails(ctx context.Context, stationId string) (pandora.StationDetails, error) {
+	ret := _m.Called(ctx, stationId)
+
+	var r0 pandora.StationDetails
+	if rf, ok := ret.Get(0).(func(context.Context, string) pandora.StationDetails); ok {
+		r0 = rf(ctx, stationId)
+	} else {
+		r0 = ret.Get(0).(pandora.StationDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stationId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStationFeedback provides a mock function with given fields: ctx, stationId, f
+func (_m *Client) GetStationFeedback(ctx context.Context, stationId string, f func([]pandora.Feedback) error) error {
+	ret := _m.Called(ctx, stationId, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func([]pandora.Feedback) error) error); ok {
+		r0 = rf(ctx, stationId, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetStations provides a mock function with given fields: ctx
+func (_m *Client) GetStations(ctx context.Context) ([]pandora.Station, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []pandora.Station
-	if rf, ok := ret.Get(0).(func() []pandora.Station); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pandora.Station); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pandora.Station)
@@ -98,8 +296,76 @@ func (_m *Client) GetStations() ([]pandora.Station, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, username, password
+func (_m *Client) Login(ctx context.Context, username string, password string) error {
+	ret := _m.Called(ctx, username, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveSeed provides a mock function with given fields: ctx, stationId, seedId
+func (_m *Client) RemoveSeed(ctx context.Context, stationId string, seedId string) error {
+	ret := _m.Called(ctx, stationId, seedId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, stationId, seedId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameStation provides a mock function with given fields: ctx, stationId, name
+func (_m *Client) RenameStation(ctx context.Context, stationId string, name string) error {
+	ret := _m.Called(ctx, stationId, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, stationId, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestartStation provides a mock function with given fields: stationId
+func (_m *Client) RestartStation(stationId string) {
+	_m.Called(stationId)
+}
+
+// Search provides a mock function with given fields: ctx, query
+func (_m *Client) Search(ctx context.Context, query string) (pandora.SearchResults, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 pandora.SearchResults
+	if rf, ok := ret.Get(0).(func(context.Context, string) pandora.SearchResults); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(pandora.SearchResults)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,13 +373,13 @@ func (_m *Client) GetStations() ([]pandora.Station, error) {
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: username, password
-func (_m *Client) Login(username string, password string) error {
-	ret := _m.Called(username, password)
+// StreamStations provides a mock function with given fields: ctx, f
+func (_m *Client) StreamStations(ctx context.Context, f func([]pandora.Station) error) error {
+	ret := _m.Called(ctx, f)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, func([]pandora.Station) error) error); ok {
+		r0 = rf(ctx, f)
 	} else {
 		r0 = ret.Error(0)
 	}
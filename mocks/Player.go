@@ -5,6 +5,7 @@ package mocks
 import (
 	audio "github.com/nlowe/mousiki/audio"
 	mock "github.com/stretchr/testify/mock"
+	time "time"
 )
 
 // Player is an autogenerated mock type for the Player type
@@ -12,6 +13,11 @@ type Player struct {
 	mock.Mock
 }
 
+// CancelPreload provides a mock function with given fields:
+func (_m *Player) CancelPreload() {
+	_m.Called()
+}
+
 // Close provides a mock function with given fields:
 func (_m *Player) Close() error {
 	ret := _m.Called()
@@ -42,6 +48,25 @@ func (_m *Player) DoneChan() <-chan error {
 	return r0
 }
 
+// FadeOut provides a mock function with given fields:
+func (_m *Player) FadeOut() {
+	_m.Called()
+}
+
+// IsMuted provides a mock function with given fields:
+func (_m *Player) IsMuted() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IsPlaying provides a mock function with given fields:
 func (_m *Player) IsPlaying() bool {
 	ret := _m.Called()
@@ -66,6 +91,11 @@ func (_m *Player) Play() {
 	_m.Called()
 }
 
+// Preload provides a mock function with given fields: url, volumeAdjustment, length
+func (_m *Player) Preload(url string, volumeAdjustment float64, length time.Duration) {
+	_m.Called(url, volumeAdjustment, length)
+}
+
 // ProgressChan provides a mock function with given fields:
 func (_m *Player) ProgressChan() <-chan audio.PlaybackProgress {
 	ret := _m.Called()
@@ -82,7 +112,41 @@ func (_m *Player) ProgressChan() <-chan audio.PlaybackProgress {
 	return r0
 }
 
-// UpdateStream provides a mock function with given fields: url, volumeAdjustment
-func (_m *Player) UpdateStream(url string, volumeAdjustment float64) {
-	_m.Called(url, volumeAdjustment)
+// Seek provides a mock function with given fields: position
+func (_m *Player) Seek(position time.Duration) {
+	_m.Called(position)
+}
+
+// SeekBy provides a mock function with given fields: offset
+func (_m *Player) SeekBy(offset time.Duration) {
+	_m.Called(offset)
+}
+
+// SetMuted provides a mock function with given fields: muted
+func (_m *Player) SetMuted(muted bool) {
+	_m.Called(muted)
+}
+
+// SetVolume provides a mock function with given fields: percent
+func (_m *Player) SetVolume(percent int) {
+	_m.Called(percent)
+}
+
+// UpdateStream provides a mock function with given fields: url, volumeAdjustment, length
+func (_m *Player) UpdateStream(url string, volumeAdjustment float64, length time.Duration) {
+	_m.Called(url, volumeAdjustment, length)
+}
+
+// Volume provides a mock function with given fields:
+func (_m *Player) Volume() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
 }
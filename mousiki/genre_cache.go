@@ -0,0 +1,56 @@
+package mousiki
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora"
+)
+
+// genreCacheTTL is how long the genre catalog is cached for. Pandora rarely
+// changes it, so there's no need to fetch it every time the picker is opened.
+const genreCacheTTL = 7 * 24 * time.Hour
+
+// GenreCache is the genre catalog as it was when it was fetched
+type GenreCache struct {
+	FetchedAt  time.Time               `json:"fetchedAt"`
+	Categories []pandora.GenreCategory `json:"categories"`
+}
+
+// Fresh returns true if the cache was fetched less than genreCacheTTL ago
+func (c GenreCache) Fresh(now time.Time) bool {
+	return now.Sub(c.FetchedAt) < genreCacheTTL
+}
+
+// DefaultGenreCachePath returns the path the genre catalog for the named api
+// is cached at, under the user's cache directory. Each api uses different IDs
+// for genre stations, so they are cached separately.
+func DefaultGenreCachePath(api string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locate cache dir: %w", err)
+	}
+
+	return filepath.Join(cacheDir, "mousiki", fmt.Sprintf("genres-%s.json", api)), nil
+}
+
+// LoadGenreCache reads a genre catalog previously written by SaveGenreCache
+func LoadGenreCache(path string) (GenreCache, error) {
+	result := GenreCache{}
+	if err := loadJSON(path, &result); err != nil {
+		return GenreCache{}, fmt.Errorf("load genre cache: %w", err)
+	}
+
+	return result, nil
+}
+
+// SaveGenreCache persists the genre catalog to path
+func SaveGenreCache(path string, c GenreCache) error {
+	if err := saveJSON(path, c, 0755, 0644); err != nil {
+		return fmt.Errorf("save genre cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package mousiki
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenreCache_Fresh(t *testing.T) {
+	now := time.Now()
+
+	require.True(t, GenreCache{FetchedAt: now.Add(-time.Hour)}.Fresh(now))
+	require.False(t, GenreCache{FetchedAt: now.Add(-genreCacheTTL)}.Fresh(now))
+}
+
+func TestSaveGenreCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mousiki")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "cache", "genres.json")
+	expected := GenreCache{
+		FetchedAt: time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+		Categories: []pandora.GenreCategory{
+			{Name: "Rock", Stations: []pandora.GenreStation{{PandoraId: "G1", Name: "Classic Rock"}}},
+		},
+	}
+
+	_, err = LoadGenreCache(path)
+	require.Error(t, err)
+
+	require.NoError(t, SaveGenreCache(path, expected))
+
+	result, err := LoadGenreCache(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+}
@@ -3,6 +3,8 @@ package mousiki
 import "github.com/nlowe/mousiki/pandora"
 
 type MessageTrackChanged struct {
-	Track   *pandora.Track
+	Track *pandora.Track
+	// Station is the station Track was played from. While shuffling, this is
+	// the station in the mix the track came from.
 	Station pandora.Station
 }
@@ -0,0 +1,60 @@
+package mousiki
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nlowe/mousiki/pandora/api"
+)
+
+const (
+	sessionDirPermissions  = 0700
+	sessionFilePermissions = 0600
+)
+
+// DefaultSessionPath returns the path sessions are persisted to, under
+// $XDG_STATE_HOME (or ~/.local/state if it is not set)
+func DefaultSessionPath() (string, error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locate state dir: %w", err)
+		}
+
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(stateDir, "mousiki", "session.json"), nil
+}
+
+// LoadSession reads a session previously written by SaveSession
+func LoadSession(path string) (api.Session, error) {
+	result := api.Session{}
+	if err := loadJSON(path, &result); err != nil {
+		return api.Session{}, fmt.Errorf("load session: %w", err)
+	}
+
+	return result, nil
+}
+
+// SaveSession persists the session to path. Since the session contains
+// credentials, both the file and its parent directory are only accessible
+// to the current user.
+func SaveSession(path string, s api.Session) error {
+	if err := saveJSON(path, s, sessionDirPermissions, sessionFilePermissions); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
+	return nil
+}
+
+// ClearSession removes a persisted session, if one exists
+func ClearSession(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("clear session: %w", err)
+	}
+
+	return nil
+}
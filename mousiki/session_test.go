@@ -0,0 +1,79 @@
+package mousiki
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nlowe/mousiki/pandora/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSessionPath(t *testing.T) {
+	original, set := os.LookupEnv("XDG_STATE_HOME")
+	defer func() {
+		if set {
+			_ = os.Setenv("XDG_STATE_HOME", original)
+		} else {
+			_ = os.Unsetenv("XDG_STATE_HOME")
+		}
+	}()
+
+	t.Run("XDG_STATE_HOME", func(t *testing.T) {
+		require.NoError(t, os.Setenv("XDG_STATE_HOME", filepath.Join("foo", "bar")))
+
+		path, err := DefaultSessionPath()
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join("foo", "bar", "mousiki", "session.json"), path)
+	})
+
+	t.Run("Home", func(t *testing.T) {
+		require.NoError(t, os.Unsetenv("XDG_STATE_HOME"))
+		home, err := os.UserHomeDir()
+		require.NoError(t, err)
+
+		path, err := DefaultSessionPath()
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(home, ".local", "state", "mousiki", "session.json"), path)
+	})
+}
+
+func TestSaveSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mousiki")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "state", "session.json")
+	expected := api.Session{
+		Username:  "un",
+		AuthToken: uuid.Must(uuid.NewRandom()).String(),
+		CSRFToken: uuid.Must(uuid.NewRandom()).String(),
+	}
+
+	require.NoError(t, SaveSession(path, expected))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(sessionFilePermissions), info.Mode().Perm())
+
+		info, err = os.Stat(filepath.Dir(path))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(sessionDirPermissions), info.Mode().Perm())
+	}
+
+	result, err := LoadSession(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+
+	require.NoError(t, ClearSession(path))
+	_, err = LoadSession(path)
+	require.Error(t, err)
+
+	require.NoError(t, ClearSession(path), "clearing a missing session is not an error")
+}
@@ -0,0 +1,52 @@
+package mousiki
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nlowe/mousiki/audio"
+)
+
+// Settings are the preferences changed from within mousiki that are restored
+// the next time it starts
+type Settings struct {
+	Volume int  `json:"volume"`
+	Muted  bool `json:"muted"`
+}
+
+// DefaultSettings are used when no settings have been saved yet
+func DefaultSettings() Settings {
+	return Settings{Volume: audio.MaxVolume}
+}
+
+// DefaultSettingsPath returns the path settings are saved to, under the user's
+// config directory
+func DefaultSettingsPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config dir: %w", err)
+	}
+
+	return filepath.Join(configDir, "mousiki", "settings.json"), nil
+}
+
+// LoadSettings reads settings previously written by SaveSettings. Settings
+// missing from the file keep their default values.
+func LoadSettings(path string) (Settings, error) {
+	result := DefaultSettings()
+	if err := loadJSON(path, &result); err != nil {
+		return DefaultSettings(), fmt.Errorf("load settings: %w", err)
+	}
+
+	return result, nil
+}
+
+// SaveSettings atomically persists settings to path
+func SaveSettings(path string, s Settings) error {
+	if err := saveJSON(path, s, 0755, 0644); err != nil {
+		return fmt.Errorf("save settings: %w", err)
+	}
+
+	return nil
+}
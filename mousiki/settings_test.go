@@ -0,0 +1,47 @@
+package mousiki
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nlowe/mousiki/audio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mousiki")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "config", "settings.json")
+	expected := Settings{Volume: 35, Muted: true}
+
+	s, err := LoadSettings(path)
+	require.Error(t, err)
+	require.Equal(t, DefaultSettings(), s, "Defaults should be returned if there are no saved settings")
+
+	require.NoError(t, SaveSettings(path, expected))
+
+	s, err = LoadSettings(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, s)
+}
+
+func TestLoadSettings_Defaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mousiki")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "settings.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"muted":true}`), 0644))
+
+	s, err := LoadSettings(path)
+	require.NoError(t, err)
+	require.Equal(t, Settings{Volume: audio.MaxVolume, Muted: true}, s)
+}
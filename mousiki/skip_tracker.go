@@ -0,0 +1,57 @@
+package mousiki
+
+import "time"
+
+const (
+	// skipLimit is how many tracks pandora lets listeners skip on a station
+	// within skipWindow
+	skipLimit  = 6
+	skipWindow = time.Hour
+)
+
+// skipTracker remembers when tracks were skipped on each station so we can
+// tell how many skips are left before pandora starts refusing them
+type skipTracker struct {
+	skips map[string][]time.Time
+	now   func() time.Time
+}
+
+func newSkipTracker() *skipTracker {
+	return &skipTracker{
+		skips: map[string][]time.Time{},
+		now:   time.Now,
+	}
+}
+
+// remaining returns how many more tracks can be skipped on station right now
+func (t *skipTracker) remaining(station string) int {
+	t.expire(station)
+
+	if n := skipLimit - len(t.skips[station]); n > 0 {
+		return n
+	}
+
+	return 0
+}
+
+// record counts a skip against the limit for station
+func (t *skipTracker) record(station string) {
+	t.expire(station)
+	t.skips[station] = append(t.skips[station], t.now())
+}
+
+// expire forgets skips on station that are older than skipWindow
+func (t *skipTracker) expire(station string) {
+	cutoff := t.now().Add(-skipWindow)
+
+	skips := t.skips[station]
+	for len(skips) > 0 && !skips[0].After(cutoff) {
+		skips = skips[1:]
+	}
+
+	if len(skips) == 0 {
+		delete(t.skips, station)
+	} else {
+		t.skips[station] = skips
+	}
+}
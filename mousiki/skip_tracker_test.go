@@ -0,0 +1,34 @@
+package mousiki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipTracker(t *testing.T) {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	sut := newSkipTracker()
+	sut.now = func() time.Time {
+		return now
+	}
+
+	require.Equal(t, skipLimit, sut.remaining("a"))
+
+	for i := 0; i < skipLimit; i++ {
+		sut.record("a")
+		now = now.Add(time.Minute)
+	}
+
+	require.Equal(t, 0, sut.remaining("a"))
+	require.Equal(t, skipLimit, sut.remaining("b"), "Skips are tracked per station")
+
+	now = now.Add(skipWindow - skipLimit*time.Minute)
+	require.Equal(t, 1, sut.remaining("a"), "Skips older than the window expire")
+
+	now = now.Add(skipWindow)
+	require.Equal(t, skipLimit, sut.remaining("a"))
+	require.Empty(t, sut.skips)
+}
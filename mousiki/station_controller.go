@@ -2,7 +2,10 @@ package mousiki
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/nlowe/mousiki/audio"
 	"github.com/nlowe/mousiki/pandora"
@@ -12,31 +15,104 @@ import (
 
 const NoStationSelected = "__mousiki_no_station"
 
+// ShuffleStationID is the ID of the station reported while shuffling tracks
+// from several stations, see StationController.Shuffle
+const ShuffleStationID = "__mousiki_shuffle"
+
+// fetchTracksRetryDelay is how long to wait before trying to fetch more tracks
+// again if we failed to fetch them and have nothing left to play
+var fetchTracksRetryDelay = 10 * time.Second
+
+// ErrDeleteCurrentStation is returned when trying to delete the station that
+// is currently playing
+var ErrDeleteCurrentStation = errors.New("can't delete the station that is currently playing")
+
+// ErrCannotStartStationFromTrack is returned when trying to create a station
+// from a track pandora doesn't allow stations to be created from
+var ErrCannotStartStationFromTrack = errors.New("can't create a station from this track")
+
+// ErrNoFeedbackToUndo is returned by UndoLastFeedback if no tracks have been
+// rated since mousiki started or the last rating was already undone
+var ErrNoFeedbackToUndo = errors.New("no feedback to undo")
+
+// ErrNothingPlaying is returned when trying to act on the current track
+// before anything has started playing
+var ErrNothingPlaying = errors.New("nothing is playing")
+
+// ErrSkipNotAllowed is returned by Skip if pandora does not allow the current
+// track to be skipped
+var ErrSkipNotAllowed = errors.New("this track can't be skipped")
+
+// ErrSkipLimitReached is returned by Skip if too many tracks were skipped on
+// the current station recently
+var ErrSkipLimitReached = errors.New("skip limit reached")
+
+// ErrNoSeedForTrack is returned by AddSeedFromTrack if pandora can't find the
+// artist or song of the track to add as a seed
+var ErrNoSeedForTrack = errors.New("can't find a seed for this track")
+
+// ErrShuffleNeedsStations is returned by Shuffle if fewer than two stations
+// were picked to shuffle
+var ErrShuffleNeedsStations = errors.New("pick at least two stations to shuffle")
+
 var noStationSelected = pandora.Station{
 	ID:   NoStationSelected,
 	Name: "No Station Selected",
 }
 
+// skipReason explains why the control loop was asked to move on from the
+// current track
+type skipReason int
+
+const (
+	// skipReasonUser is used when a track is skipped or banned, pandora counts
+	// these skips against the skip limit
+	skipReasonUser skipReason = iota
+	// skipReasonStationChange is used when the current track is cut off
+	// because a different station was picked
+	skipReasonStationChange
+)
+
 type narrativeCache struct {
 	station   string
 	track     string
 	narrative pandora.Narrative
 }
 
+// ratedTrack remembers the last feedback we gave so it can be undone
+type ratedTrack struct {
+	feedback       pandora.Feedback
+	track          *pandora.Track
+	previousRating pandora.TrackRating
+}
+
 type StationController struct {
 	stationLock sync.Mutex
 	station     pandora.Station
 	pandora     api.Client
+	reporter    api.TrackReporter
 	player      audio.Player
 
+	// mix holds the stations being shuffled, tracks are fetched from each of
+	// them in turn starting at mixNext
+	mix     []pandora.Station
+	mixNext int
+
 	playing *pandora.Track
 	queue   []pandora.Track
 
-	skip           chan struct{}
+	skip chan skipReason
+	// switches counts station changes so the control loop can tell if the
+	// station changed while it wasn't holding stationLock
+	switches       int
+	skips          *skipTracker
+	bingeSkipping  map[string]bool
 	notifications  chan MessageTrackChanged
 	stationChanged chan pandora.Station
 
 	narrativeCache narrativeCache
+	lastFeedback   *ratedTrack
+	genreCachePath string
 
 	log logrus.FieldLogger
 }
@@ -45,11 +121,15 @@ func (n narrativeCache) matches(t *pandora.Track) bool {
 	return n.station == t.StationId && n.track == t.MusicId
 }
 
-func NewStationController(c api.Client, p audio.Player) *StationController {
+func NewStationController(c api.Client, r api.TrackReporter, p audio.Player) *StationController {
 	return &StationController{
-		pandora: c,
-		player:  p,
-		station: noStationSelected,
+		pandora:  c,
+		reporter: r,
+		player:   p,
+		station:  noStationSelected,
+
+		skips:         newSkipTracker(),
+		bingeSkipping: map[string]bool{},
 
 		notifications:  make(chan MessageTrackChanged, 1),
 		stationChanged: make(chan pandora.Station, 1),
@@ -62,43 +142,115 @@ func NewStationController(c api.Client, p audio.Player) *StationController {
 }
 
 func (s *StationController) Play(ctx context.Context) {
+	s.stationLock.Lock()
 	if s.station.ID == NoStationSelected {
+		s.stationLock.Unlock()
 		s.log.Error("No Station Selected, nothing to play")
 		return
 	}
 
-	s.skip = make(chan struct{}, 1)
+	s.skip = make(chan skipReason, 1)
+	s.stationLock.Unlock()
 
+	// Let pandora know why we need more tracks so skipping can be accounted for
+	reason := api.FragmentRequestReasonNormal
 	for {
 		// TODO: Configure prefetch limit?
 		s.stationLock.Lock()
+		switches := s.switches
 		if len(s.queue) <= 1 {
-			s.log.Info("Fetching more tracks")
-			tracks, err := s.pandora.GetMoreTracks(s.station.ID)
+			from := s.nextStationToFetch()
+			log := s.log
+			s.stationLock.Unlock()
+
+			// Pandora is slow to respond at times and the client retries
+			// failed requests, so don't hold up the UI while we wait
+			log.WithField("from", from).Info("Fetching more tracks")
+			fragment, err := s.pandora.GetMoreTracks(ctx, from.ID, reason)
+
+			s.stationLock.Lock()
+			if s.switchedSince(switches) {
+				// These tracks are from the station we just switched away from
+				s.stationLock.Unlock()
+				reason = api.FragmentRequestReasonNormal
+				continue
+			}
+
 			if err != nil {
-				// TODO: More graceful error handling
-				s.log.WithError(err).Fatal("Failed to fetch more tracks")
+				log.WithError(err).Error("Failed to fetch more tracks")
+			} else {
+				s.bingeSkipping[from.ID] = fragment.IsBingeSkipping
+				for _, t := range fragment.Tracks {
+					// Remember where the track came from so it can be traced
+					// back to its station while shuffling
+					if t.StationId == "" {
+						t.StationId = from.ID
+					}
+
+					s.queue = append(s.queue, t)
+				}
 			}
 
-			s.queue = append(s.queue, tracks...)
+			// The client already retries transient failures, and fragments
+			// can come back empty once ads and unplayable items are dropped,
+			// so back off for a while before trying again if there's nothing
+			// to play
+			if len(s.queue) == 0 {
+				s.stationLock.Unlock()
+				if err == nil {
+					log.WithField("from", from).Warn("Got no playable tracks")
+				}
+
+				select {
+				case <-time.After(fetchTracksRetryDelay):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
 		}
 
 		s.playing, s.queue = &s.queue[0], s.queue[1:]
+		playing, log := s.playing, s.log
+		changed := MessageTrackChanged{Track: playing, Station: s.originOf(playing)}
+		s.stationLock.Unlock()
 
-		s.log.WithField("track", s.playing.String()).Info("Playing new track")
-		select {
-		case s.notifications <- MessageTrackChanged{Track: s.playing, Station: s.station}:
+		log.WithField("track", playing.String()).Info("Playing new track")
+		s.notifications <- changed
+
+		// Starting the stream waits for the track to download, so it happens
+		// without the lock held too
+		s.player.UpdateStream(playing.AudioUrl, playing.FileGain, playing.Length())
+
+		s.stationLock.Lock()
+		if s.switchedSince(switches) {
+			s.stationLock.Unlock()
+			reason = api.FragmentRequestReasonNormal
+			continue
 		}
-		s.player.UpdateStream(s.playing.AudioUrl, s.playing.FileGain)
+
+		track := *playing
+		s.report(ctx, log, "audio receipt", track, s.reporter.ReportAudioReceipt)
+		s.preloadNext()
 		s.stationLock.Unlock()
 
 		select {
-		case <-s.skip:
-			s.log.Info("Skipping to next track")
+		case why := <-s.skip:
+			if why == skipReasonStationChange {
+				// The new station is starting fresh, nothing was skipped on it
+				log.Info("Station changed, moving on")
+				reason = api.FragmentRequestReasonNormal
+				break
+			}
+
+			log.Info("Skipping to next track")
+			s.report(ctx, log, "skip", track, s.reporter.ReportSkip)
+			reason = api.FragmentRequestReasonSkip
 		case err := <-s.player.DoneChan():
+			reason = api.FragmentRequestReasonNormal
 			if err != nil {
 				// TODO: Bubble up error?
-				s.log.WithError(err).Error("Error during playback")
+				log.WithError(err).Error("Error during playback")
 			}
 		case <-ctx.Done():
 			return
@@ -106,63 +258,363 @@ func (s *StationController) Play(ctx context.Context) {
 	}
 }
 
-func (s *StationController) Skip() {
-	if s.skip != nil {
-		s.player.Pause()
-		s.skip <- struct{}{}
+// switchedSince returns true if the station changed after the control loop saw
+// the specified number of station switches. The station change is consumed so
+// that it doesn't also skip the first track of the new station. The caller
+// must hold stationLock.
+func (s *StationController) switchedSince(switches int) bool {
+	if s.switches == switches {
+		return false
 	}
+
+	select {
+	case <-s.skip:
+	default:
+	}
+
+	return true
 }
 
-func (s *StationController) NowPlaying() *pandora.Track {
-	return s.playing
+// preloadNext lets the player start fetching the next track in the queue so
+// it can start playing as soon as the current track ends. The caller must hold
+// stationLock.
+func (s *StationController) preloadNext() {
+	if len(s.queue) == 0 {
+		s.player.CancelPreload()
+		return
+	}
+
+	s.player.Preload(s.queue[0].AudioUrl, s.queue[0].FileGain, s.queue[0].Length())
+}
+
+// unqueue drops queued tracks of the song with the specified music ID so a
+// song that was just banned or timed out doesn't come up again, which can
+// happen while shuffling stations. The caller must hold stationLock.
+func (s *StationController) unqueue(musicId string) {
+	queue := []pandora.Track{}
+	for _, t := range s.queue {
+		if t.MusicId != musicId {
+			queue = append(queue, t)
+		}
+	}
+
+	s.queue = queue
+	s.preloadNext()
+}
+
+// nextStationToFetch returns the station to fetch more tracks from. While
+// shuffling, each station in the mix takes a turn.
+func (s *StationController) nextStationToFetch() pandora.Station {
+	if len(s.mix) == 0 {
+		return s.station
+	}
+
+	station := s.mix[s.mixNext%len(s.mix)]
+	s.mixNext = (s.mixNext + 1) % len(s.mix)
+
+	return station
+}
+
+// StationOf returns the station the specified track was played from. While
+// shuffling, this is the station in the mix the track was fetched from.
+func (s *StationController) StationOf(t *pandora.Track) pandora.Station {
+	return s.originOf(t)
+}
+
+func (s *StationController) originOf(t *pandora.Track) pandora.Station {
+	if t != nil {
+		for _, station := range s.mix {
+			if station.ID == t.StationId {
+				return station
+			}
+		}
+	}
+
+	return s.station
+}
+
+// isPlayingFrom returns true if tracks are currently being played from the
+// station with the specified ID, either directly or as part of a shuffle
+func (s *StationController) isPlayingFrom(stationId string) bool {
+	if s.station.ID == stationId {
+		return true
+	}
+
+	for _, station := range s.mix {
+		if station.ID == stationId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// report lets pandora know what happened to a track in the background. Reports
+// are best-effort, failing to send one shouldn't interrupt playback.
+func (s *StationController) report(ctx context.Context, log logrus.FieldLogger, kind string, t pandora.Track, f func(context.Context, pandora.Track) error) {
+	go func() {
+		if err := f(ctx, t); err != nil {
+			log.WithError(err).WithField("track", t.String()).Warnf("Failed to report %s", kind)
+		}
+	}()
+}
+
+// Skip moves on to the next track if pandora allows it. Skips that count
+// against the skip limit are tracked per station, if the current track can't
+// be skipped ErrSkipNotAllowed or ErrSkipLimitReached is returned.
+func (s *StationController) Skip() error {
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	if err := s.useSkip(); err != nil {
+		return err
+	}
+
+	s.skipTrack(skipReasonUser)
+	return nil
 }
 
-// TODO: There are endpoints listed for removing feedback, but they're not documented
-func (s *StationController) ProvideFeedback(f pandora.TrackRating) error {
+// SkipsRemaining returns how many more tracks can be skipped on the current
+// station. If the current track can't be skipped, the reason is returned.
+func (s *StationController) SkipsRemaining() (int, error) {
 	s.stationLock.Lock()
 	defer s.stationLock.Unlock()
 
-	log := s.log.WithField("track", s.playing)
+	return s.skips.remaining(s.originOf(s.playing).ID), s.canSkip()
+}
+
+func (s *StationController) canSkip() error {
+	if s.playing == nil || s.playing.AllowSkipTrackWithoutLimit {
+		return nil
+	}
+
+	if !s.playing.AllowSkip {
+		return ErrSkipNotAllowed
+	}
+
+	station := s.originOf(s.playing).ID
+	if s.bingeSkipping[station] || s.skips.remaining(station) == 0 {
+		return ErrSkipLimitReached
+	}
+
+	return nil
+}
+
+// useSkip checks if the current track can be skipped and counts it against
+// the skip limit if it needs to
+func (s *StationController) useSkip() error {
+	if err := s.canSkip(); err != nil {
+		return err
+	}
+
+	if s.playing != nil && !s.playing.AllowSkipTrackWithoutLimit {
+		s.skips.record(s.originOf(s.playing).ID)
+	}
 
-	if s.playing.Rating == f {
+	return nil
+}
+
+// skipTrack moves on to the next track without checking the skip limit. If
+// the control loop hasn't picked up an earlier skip yet, it is replaced, but a
+// pending station change is never turned into a skip that would be reported to
+// pandora. The caller must hold stationLock.
+func (s *StationController) skipTrack(why skipReason) {
+	if s.skip == nil {
+		return
+	}
+
+	select {
+	case pending := <-s.skip:
+		if pending == skipReasonStationChange {
+			why = pending
+		}
+	default:
+	}
+
+	s.player.FadeOut()
+	s.skip <- why
+}
+
+func (s *StationController) NowPlaying() *pandora.Track {
+	return s.playing
+}
+
+func (s *StationController) ProvideFeedback(ctx context.Context, f pandora.TrackRating) error {
+	s.stationLock.Lock()
+	playing := s.playing
+	if playing == nil {
+		s.stationLock.Unlock()
+		return ErrNothingPlaying
+	}
+
+	log := s.log.WithField("track", playing)
+	if playing.Rating == f {
+		s.stationLock.Unlock()
 		log.Warn("Not adding duplicate feedback")
 		return nil
 	}
+	s.stationLock.Unlock()
 
+	// The client retries failed requests, so don't hold up playback while
+	// pandora takes the feedback
 	if f == pandora.TrackRatingTired {
 		log.Info("Temporarily timing-out song")
-		err := s.pandora.AddTired(s.playing.TrackToken)
-
-		if err == nil {
-			// TODO: The UI does not currently differentiate between banned and tired songs
-			s.playing.Rating = pandora.TrackRatingBan
-			select {
-			case s.skip <- struct{}{}:
-			}
+		if err := s.pandora.AddTired(ctx, playing.TrackToken); err != nil {
+			return err
 		}
 
-		return err
+		s.stationLock.Lock()
+		defer s.stationLock.Unlock()
+
+		// TODO: The UI does not currently differentiate between banned and tired songs
+		playing.Rating = pandora.TrackRatingBan
+		s.unqueue(playing.MusicId)
+		s.skipRatedTrack(log, playing)
+
+		return nil
+	}
+
+	positive := true
+	if f == pandora.TrackRatingBan {
+		log.Info("Banning song")
+		positive = false
 	} else {
-		positive := true
-		if f == pandora.TrackRatingBan {
-			log.Info("Banning song")
-			positive = false
-		} else {
-			log.Info("Loving song")
-		}
+		log.Info("Loving song")
+	}
 
-		err := s.pandora.AddFeedback(s.playing.TrackToken, positive)
-		if err == nil {
-			s.playing.Rating = f
-			if !positive {
-				select {
-				case s.skip <- struct{}{}:
-				}
-			}
-		}
+	feedback, err := s.pandora.AddFeedback(ctx, playing.TrackToken, positive)
+	if err != nil {
+		return err
+	}
+
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	s.lastFeedback = &ratedTrack{
+		feedback:       feedback,
+		track:          playing,
+		previousRating: playing.Rating,
+	}
+
+	playing.Rating = f
+	if !positive {
+		s.unqueue(playing.MusicId)
+		s.skipRatedTrack(log, playing)
+	}
+
+	return nil
+}
+
+// skipRatedTrack skips a track that was just banned or timed out. Like pandora,
+// the rating is kept but the track keeps playing if it can't be skipped. Nothing
+// is skipped if a different track started playing while the rating was sent.
+// The caller must hold stationLock.
+func (s *StationController) skipRatedTrack(log logrus.FieldLogger, rated *pandora.Track) {
+	if s.playing != rated {
+		return
+	}
+
+	if err := s.useSkip(); err != nil {
+		log.WithError(err).Warn("Not skipping rated track")
+		return
+	}
+
+	s.skipTrack(skipReasonUser)
+}
+
+// UndoLastFeedback removes the last thumbs up or thumbs down given with
+// ProvideFeedback, returning the track it was given to. Banned tracks that
+// were skipped are not played again.
+func (s *StationController) UndoLastFeedback(ctx context.Context) (*pandora.Track, error) {
+	s.stationLock.Lock()
+	last, log := s.lastFeedback, s.log
+	s.stationLock.Unlock()
+
+	if last == nil {
+		return nil, ErrNoFeedbackToUndo
+	}
+
+	log.WithField("track", last.track).Info("Undoing feedback")
+	if err := s.pandora.DeleteFeedback(ctx, last.feedback.ID); err != nil {
+		return nil, err
+	}
 
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	last.track.Rating = last.previousRating
+	if s.lastFeedback == last {
+		s.lastFeedback = nil
+	}
+
+	return last.track, nil
+}
+
+// BookmarkCurrentSong bookmarks the song that is currently playing
+func (s *StationController) BookmarkCurrentSong(ctx context.Context) (pandora.Bookmark, error) {
+	s.stationLock.Lock()
+	playing, log := s.playing, s.log
+	s.stationLock.Unlock()
+
+	if playing == nil {
+		return pandora.Bookmark{}, ErrNothingPlaying
+	}
+
+	log.WithField("track", playing).Info("Bookmarking song")
+	bookmark, err := s.pandora.BookmarkSong(ctx, playing.TrackToken)
+	if err == nil {
+		s.stationLock.Lock()
+		playing.IsBookmarked = true
+		s.stationLock.Unlock()
+	}
+
+	return bookmark, err
+}
+
+// BookmarkCurrentArtist bookmarks the artist of the song that is currently
+// playing
+func (s *StationController) BookmarkCurrentArtist(ctx context.Context) (pandora.Bookmark, error) {
+	s.stationLock.Lock()
+	playing, log := s.playing, s.log
+	s.stationLock.Unlock()
+
+	if playing == nil {
+		return pandora.Bookmark{}, ErrNothingPlaying
+	}
+
+	log.WithField("artist", playing.ArtistName).Info("Bookmarking artist")
+	return s.pandora.BookmarkArtist(ctx, playing.TrackToken)
+}
+
+// Bookmarks lists the bookmarked artists and songs
+func (s *StationController) Bookmarks(ctx context.Context) (pandora.Bookmarks, error) {
+	return s.pandora.GetBookmarks(ctx)
+}
+
+// StationFeedback lists the thumbs up and thumbs down given to tracks on
+// station a page at a time, see api.Client.GetStationFeedback
+func (s *StationController) StationFeedback(ctx context.Context, station pandora.Station, f func(page []pandora.Feedback) error) error {
+	return s.pandora.GetStationFeedback(ctx, station.ID, f)
+}
+
+// DeleteFeedback removes a thumbs up or thumbs down from a track
+func (s *StationController) DeleteFeedback(ctx context.Context, feedback pandora.Feedback) error {
+	if err := s.pandora.DeleteFeedback(ctx, feedback.ID); err != nil {
 		return err
 	}
+
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	s.log.WithField("feedback", feedback).Info("Deleted Feedback")
+
+	// Forget about the feedback so it can't be undone twice
+	if s.lastFeedback != nil && s.lastFeedback.feedback.ID == feedback.ID {
+		s.lastFeedback.track.Rating = s.lastFeedback.previousRating
+		s.lastFeedback = nil
+	}
+
+	return nil
 }
 
 func (s *StationController) UpNext() []pandora.Track {
@@ -172,8 +624,256 @@ func (s *StationController) UpNext() []pandora.Track {
 	return result
 }
 
-func (s *StationController) ListStations() ([]pandora.Station, error) {
-	return s.pandora.GetStations()
+func (s *StationController) ListStations(ctx context.Context) ([]pandora.Station, error) {
+	return s.pandora.GetStations(ctx)
+}
+
+// StreamStations lists stations a page at a time, see api.Client.StreamStations
+func (s *StationController) StreamStations(ctx context.Context, f func(page []pandora.Station) error) error {
+	return s.pandora.StreamStations(ctx, f)
+}
+
+// CreateStation creates a new station seeded from the artist or song with the
+// specified pandora ID
+func (s *StationController) CreateStation(ctx context.Context, pandoraId string) (pandora.Station, error) {
+	station, err := s.pandora.CreateStation(ctx, pandoraId)
+	if err == nil {
+		s.log.WithField("newStation", station).Info("Created Station")
+	}
+
+	return station, err
+}
+
+// CreateStationFromTrack creates a new station seeded from track, usually the
+// one that is currently playing
+func (s *StationController) CreateStationFromTrack(ctx context.Context, track *pandora.Track) (pandora.Station, error) {
+	if track == nil || !track.AllowStartStationFromTrack {
+		return pandora.Station{}, ErrCannotStartStationFromTrack
+	}
+
+	return s.CreateStation(ctx, track.PandoraId)
+}
+
+// Search finds artists, tracks, and genres that a station can be created from
+func (s *StationController) Search(ctx context.Context, query string) (pandora.SearchResults, error) {
+	return s.pandora.Search(ctx, query)
+}
+
+// CacheGenresAt enables caching the genre catalog fetched by GenreCategories
+// at path
+func (s *StationController) CacheGenresAt(path string) {
+	s.genreCachePath = path
+}
+
+// GenreCategories lists the genre stations pandora offers by category. If
+// caching is enabled, the catalog is only fetched again once the cache is
+// stale, and the stale catalog is used if it can't be fetched.
+func (s *StationController) GenreCategories(ctx context.Context) ([]pandora.GenreCategory, error) {
+	s.stationLock.Lock()
+	log := s.log.WithField("path", s.genreCachePath)
+	s.stationLock.Unlock()
+
+	var stale []pandora.GenreCategory
+	if s.genreCachePath != "" {
+		cached, err := LoadGenreCache(s.genreCachePath)
+		if err != nil {
+			log.WithError(err).Debug("No cached genre catalog")
+		} else if cached.Fresh(time.Now()) {
+			return cached.Categories, nil
+		} else {
+			stale = cached.Categories
+		}
+	}
+
+	categories, err := s.pandora.GetGenreCategories(ctx)
+	if err != nil {
+		if stale != nil {
+			log.WithError(err).Warn("Failed to refresh genre catalog, using the cached catalog")
+			return stale, nil
+		}
+
+		return nil, err
+	}
+
+	if s.genreCachePath != "" {
+		if err := SaveGenreCache(s.genreCachePath, GenreCache{FetchedAt: time.Now(), Categories: categories}); err != nil {
+			log.WithError(err).Warn("Failed to cache genre catalog")
+		}
+	}
+
+	return categories, nil
+}
+
+// StationDetails fetches the seeds and feedback counts for the specified
+// station
+func (s *StationController) StationDetails(ctx context.Context, station pandora.Station) (pandora.StationDetails, error) {
+	return s.pandora.GetStationDetails(ctx, station.ID)
+}
+
+// AddSeed adds the artist or track with the specified pandora ID as a seed for
+// station
+func (s *StationController) AddSeed(ctx context.Context, station pandora.Station, pandoraId string) (pandora.StationSeed, error) {
+	seed, err := s.pandora.AddSeed(ctx, station.ID, pandoraId)
+	if err == nil {
+		s.log.WithFields(logrus.Fields{
+			"seedStation": station,
+			"seed":        seed,
+		}).Info("Added Seed")
+
+		s.clearQueueFor(station)
+	}
+
+	return seed, err
+}
+
+// AddSeedFromTrack adds the artist or song of track as a seed for station,
+// depending on kind. Pandora usually doesn't tell us the IDs to seed a station
+// with for the tracks it plays, so they are looked up with a search.
+func (s *StationController) AddSeedFromTrack(ctx context.Context, station pandora.Station, track *pandora.Track, kind pandora.SearchResultType) (pandora.StationSeed, error) {
+	pandoraId, err := s.seedIdFor(ctx, track, kind)
+	if err != nil {
+		return pandora.StationSeed{}, err
+	}
+
+	return s.AddSeed(ctx, station, pandoraId)
+}
+
+// seedIdFor finds the pandora ID of the artist or song of track that can be
+// used as a station seed
+func (s *StationController) seedIdFor(ctx context.Context, track *pandora.Track, kind pandora.SearchResultType) (string, error) {
+	var query string
+	switch kind {
+	case pandora.SearchResultTypeArtist:
+		if id := track.ArtistSeedId(); id != "" {
+			return id, nil
+		}
+
+		query = track.ArtistName
+	case pandora.SearchResultTypeTrack:
+		if id := track.SongSeedId(); id != "" {
+			return id, nil
+		}
+
+		query = fmt.Sprintf("%s %s", track.SongTitle, track.ArtistName)
+	default:
+		return "", fmt.Errorf("%w: unsupported seed type %s", ErrNoSeedForTrack, kind)
+	}
+
+	results, err := s.pandora.Search(ctx, query)
+	if err != nil {
+		return "", err
+	}
+
+	var result pandora.SearchResult
+	var ok bool
+	if kind == pandora.SearchResultTypeArtist {
+		result, ok = results.FindArtist(track.ArtistName)
+	} else {
+		result, ok = results.FindTrack(track.SongTitle, track.ArtistName)
+	}
+
+	if !ok {
+		return "", ErrNoSeedForTrack
+	}
+
+	return result.PandoraId, nil
+}
+
+func (s *StationController) RemoveSeed(ctx context.Context, station pandora.Station, seed pandora.StationSeed) error {
+	err := s.pandora.RemoveSeed(ctx, station.ID, seed.SeedId)
+	if err == nil {
+		s.log.WithFields(logrus.Fields{
+			"seedStation": station,
+			"seed":        seed,
+		}).Info("Removed Seed")
+
+		s.clearQueueFor(station)
+	}
+
+	return err
+}
+
+// clearQueueFor drops queued tracks if station is playing so that the next
+// tracks pick up changes to the station. While shuffling, only the tracks
+// from station are dropped.
+func (s *StationController) clearQueueFor(station pandora.Station) {
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	if s.station.ID == station.ID {
+		s.queue = []pandora.Track{}
+		s.preloadNext()
+		return
+	}
+
+	if !s.isPlayingFrom(station.ID) {
+		return
+	}
+
+	queue := []pandora.Track{}
+	for _, t := range s.queue {
+		if t.StationId != station.ID {
+			queue = append(queue, t)
+		}
+	}
+
+	s.queue = queue
+	s.preloadNext()
+}
+
+// RenameStation renames the specified station and returns the updated
+// station. If the station is currently playing, listeners of StationChanged
+// are notified of the new name.
+func (s *StationController) RenameStation(ctx context.Context, station pandora.Station, name string) (pandora.Station, error) {
+	if err := s.pandora.RenameStation(ctx, station.ID, name); err != nil {
+		return station, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"renamedStation": station,
+		"name":           name,
+	}).Info("Renamed Station")
+	station.Name = name
+
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	for i := range s.mix {
+		if s.mix[i].ID == station.ID {
+			s.mix[i].Name = name
+		}
+	}
+
+	if s.station.ID == station.ID {
+		s.station.Name = name
+
+		select {
+		case s.stationChanged <- s.station:
+		default:
+		}
+	}
+
+	return station, nil
+}
+
+// DeleteStation deletes the specified station. The currently playing station
+// and stations being shuffled cannot be deleted, switch to a different station
+// first.
+func (s *StationController) DeleteStation(ctx context.Context, station pandora.Station) error {
+	s.stationLock.Lock()
+	current := s.isPlayingFrom(station.ID)
+	s.stationLock.Unlock()
+
+	if current {
+		return ErrDeleteCurrentStation
+	}
+
+	if err := s.pandora.DeleteStation(ctx, station.ID); err != nil {
+		return err
+	}
+
+	s.log.WithField("deletedStation", station).Info("Deleted Station")
+	return nil
 }
 
 func (s *StationController) SwitchStations(station pandora.Station) {
@@ -185,15 +885,62 @@ func (s *StationController) SwitchStations(station pandora.Station) {
 		return
 	}
 
-	s.log.WithField("newStation", station).Info("Switching Stations")
+	s.switchTo(station, nil)
+}
+
+// Shuffle plays tracks from each of the specified stations in turn. The
+// station reported by StationChanged and CurrentStation is a placeholder with
+// the ID ShuffleStationID, use StationOf to find out which station a track was
+// played from.
+func (s *StationController) Shuffle(stations []pandora.Station) error {
+	if len(stations) < 2 {
+		return ErrShuffleNeedsStations
+	}
+
+	s.stationLock.Lock()
+	defer s.stationLock.Unlock()
+
+	mix := make([]pandora.Station, len(stations))
+	copy(mix, stations)
+
+	s.switchTo(pandora.Station{
+		ID:   ShuffleStationID,
+		Name: fmt.Sprintf("Shuffle (%d stations)", len(mix)),
+	}, mix)
+
+	return nil
+}
+
+// switchTo starts playing tracks from station, or from the stations in mix if
+// shuffling. The caller must hold stationLock.
+func (s *StationController) switchTo(station pandora.Station, mix []pandora.Station) {
+	s.log.WithFields(logrus.Fields{
+		"newStation": station,
+		"mix":        mix,
+	}).Info("Switching Stations")
 
 	// Change the station and clear the queue to force the next control loop
 	// to fetch tracks from the new station
 	s.station = station
+	s.mix = mix
+	s.mixNext = 0
+	s.switches++
 	s.queue = []pandora.Track{}
+	s.preloadNext()
+
+	// Start the new station's playlist over instead of continuing from the
+	// last time it was played
+	if len(mix) == 0 {
+		s.pandora.RestartStation(station.ID)
+	}
+
+	for _, m := range mix {
+		s.pandora.RestartStation(m.ID)
+	}
 
-	// Try to skip immediately in case we're currently playing a track
-	s.Skip()
+	// Try to skip immediately in case we're currently playing a track.
+	// Changing stations doesn't count against the skip limit.
+	s.skipTrack(skipReasonStationChange)
 
 	// Reset the logger to pick up the new station name
 	s.log = logrus.WithFields(logrus.Fields{
@@ -204,14 +951,14 @@ func (s *StationController) SwitchStations(station pandora.Station) {
 	s.stationChanged <- station
 }
 
-func (s *StationController) ExplainCurrentTrack() (pandora.Narrative, error) {
+func (s *StationController) ExplainCurrentTrack(ctx context.Context) (pandora.Narrative, error) {
 	if s.narrativeCache.matches(s.playing) {
 		s.log.Debug("Returning Cached Narrative")
 		return s.narrativeCache.narrative, nil
 	}
 
 	s.log.Debug("Fetching Narrative")
-	result, err := s.pandora.GetNarrative(s.playing.StationId, s.playing.MusicId)
+	result, err := s.pandora.GetNarrative(ctx, s.playing.StationId, s.playing.MusicId)
 	if err == nil {
 		s.narrativeCache = narrativeCache{
 			station:   s.playing.StationId,
@@ -2,13 +2,19 @@ package mousiki
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/magiconair/properties/assert"
 	"github.com/nlowe/mousiki/mocks"
 	"github.com/nlowe/mousiki/pandora"
+	"github.com/nlowe/mousiki/pandora/api"
 	"github.com/nlowe/mousiki/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -20,17 +26,24 @@ func TestStationController_Play(t *testing.T) {
 		Name: "Dummy Station Radio",
 	}
 	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
 	p := &mocks.Player{}
-	sut := NewStationController(c, p)
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+	p.On("CancelPreload").Return()
+	sut := NewStationController(c, r, p)
 	sut.log = testutil.NopLogger()
 
 	next := 0
 	playlist := []string{"1", "2", "3", "4"}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	c.On("GetMoreTracks", mock.Anything).Run(func(args mock.Arguments) {
-		require.Equal(t, s.ID, args.String(0))
-	}).Return(func(u string) []pandora.Track {
+	c.On("GetMoreTracks", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		require.Equal(t, ctx, args.Get(0))
+		require.Equal(t, s.ID, args.String(1))
+		require.Equal(t, api.FragmentRequestReasonNormal, args.Get(2))
+	}).Return(func(_ context.Context, u string, _ api.FragmentRequestReason) pandora.Fragment {
 		a := testutil.MakeTrack()
 		a.AudioUrl = playlist[next]
 		next++
@@ -39,17 +52,17 @@ func TestStationController_Play(t *testing.T) {
 		b.AudioUrl = playlist[next]
 		next++
 
-		return []pandora.Track{
+		return pandora.Fragment{Tracks: []pandora.Track{
 			a,
 			b,
-		}
+		}}
 	}, nil)
 
 	doneCh := make(chan error, 1)
 	var played []string
 
 	var doneChRet <-chan error = doneCh
-	p.On("UpdateStream", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+	p.On("UpdateStream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 		url := args.String(0)
 		played = append(played, url)
 		assert.Equal(t, url, (<-sut.NotificationChan()).Track.AudioUrl)
@@ -70,11 +83,420 @@ func TestStationController_Play(t *testing.T) {
 	require.Equal(t, []string{"1", "2", "3"}, played)
 }
 
+func TestStationController_Play_ReportsTracks(t *testing.T) {
+	s := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Dummy Station Radio",
+	}
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	p := &mocks.Player{}
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+	p.On("CancelPreload").Return()
+	sut := NewStationController(c, r, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := testutil.MakeTrack()
+	a.AudioUrl = "1"
+	a.AudioReceiptURL = "receipt-1"
+	a.AudioSkipURL = "skip-1"
+	a.AllowSkip = true
+
+	b := testutil.MakeTrack()
+	b.AudioUrl = "2"
+	b.AudioReceiptURL = "receipt-2"
+	b.AudioSkipURL = "skip-2"
+
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{a, b}}, nil)
+
+	receipts := make(chan string, 2)
+	skips := make(chan string, 1)
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		receipts <- args.Get(1).(pandora.Track).AudioReceiptURL
+	}).Return(nil)
+	r.On("ReportSkip", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		skips <- args.Get(1).(pandora.Track).AudioSkipURL
+	}).Return(fmt.Errorf("dummy"))
+
+	p.On("FadeOut").Return()
+	p.On("UpdateStream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		<-sut.NotificationChan()
+		if args.String(0) == "1" {
+			go func() {
+				if err := sut.Skip(); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+	})
+	p.On("DoneChan").Return(nil)
+
+	sut.SwitchStations(s)
+	go sut.Play(ctx)
+
+	var reported []string
+	for len(reported) < 3 {
+		select {
+		case url := <-receipts:
+			reported = append(reported, url)
+		case url := <-skips:
+			reported = append(reported, url)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for tracks to be reported")
+		}
+	}
+
+	require.ElementsMatch(t, []string{"receipt-1", "skip-1", "receipt-2"}, reported)
+}
+
+func TestStationController_Play_RecoversFromFetchErrors(t *testing.T) {
+	fetchTracksRetryDelay = time.Millisecond
+	defer func() {
+		fetchTracksRetryDelay = 10 * time.Second
+	}()
+
+	s := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Dummy Station Radio",
+	}
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
+	p := &mocks.Player{}
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+	p.On("CancelPreload").Return()
+	sut := NewStationController(c, r, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	track := testutil.MakeTrack()
+	track.AudioUrl = "1"
+
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{}, fmt.Errorf("dummy")).Once()
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{track}}, nil)
+
+	done := make(chan struct{})
+	p.On("UpdateStream", "1", mock.Anything, 123*time.Second).Run(func(_ mock.Arguments) {
+		<-sut.NotificationChan()
+		cancel()
+		close(done)
+	})
+	p.On("DoneChan").Return(nil)
+
+	sut.SwitchStations(s)
+	go sut.Play(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for playback to recover")
+	}
+
+	c.AssertNumberOfCalls(t, "GetMoreTracks", 2)
+}
+
+func TestStationController_Play_RecoversFromEmptyFragments(t *testing.T) {
+	fetchTracksRetryDelay = time.Millisecond
+	defer func() {
+		fetchTracksRetryDelay = 10 * time.Second
+	}()
+
+	s := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Dummy Station Radio",
+	}
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
+	p := &mocks.Player{}
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+	p.On("CancelPreload").Return()
+	sut := NewStationController(c, r, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	track := testutil.MakeTrack()
+	track.AudioUrl = "1"
+
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{}}, nil).Once()
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{track}}, nil)
+
+	done := make(chan struct{})
+	p.On("UpdateStream", "1", mock.Anything, 123*time.Second).Run(func(_ mock.Arguments) {
+		<-sut.NotificationChan()
+		cancel()
+		close(done)
+	})
+	p.On("DoneChan").Return(nil)
+
+	sut.SwitchStations(s)
+	go sut.Play(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for playback to recover")
+	}
+
+	c.AssertNumberOfCalls(t, "GetMoreTracks", 2)
+}
+
+func TestStationController_Play_PreloadsNextTrack(t *testing.T) {
+	s := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Dummy Station Radio",
+	}
+	other := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Other Station Radio",
+	}
+
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
+	p := &mocks.Player{}
+	sut := NewStationController(c, r, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := testutil.MakeTrack()
+	a.AudioUrl = "1"
+	b := testutil.MakeTrack()
+	b.AudioUrl = "2"
+
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{a, b}}, nil)
+	c.On("GetMoreTracks", mock.Anything, other.ID, mock.Anything).Return(pandora.Fragment{}, fmt.Errorf("dummy"))
+
+	calls := make(chan string, 10)
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		require.Equal(t, 123*time.Second, args.Get(2))
+		calls <- "preload " + args.String(0)
+	})
+	p.On("CancelPreload").Run(func(_ mock.Arguments) {
+		calls <- "cancel"
+	})
+	p.On("FadeOut").Return()
+	p.On("UpdateStream", mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+		<-sut.NotificationChan()
+	})
+	p.On("DoneChan").Return(nil)
+
+	expectCall := func(expected string) {
+		select {
+		case call := <-calls:
+			require.Equal(t, expected, call)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for %s", expected)
+		}
+	}
+
+	sut.SwitchStations(s)
+	<-sut.StationChanged()
+	expectCall("cancel")
+
+	go sut.Play(ctx)
+	expectCall("preload 2")
+
+	sut.SwitchStations(other)
+	expectCall("cancel")
+}
+
+func TestStationController_Play_DoesNotHoldLockWhileFetching(t *testing.T) {
+	s := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Dummy Station Radio",
+	}
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	p := &mocks.Player{}
+	p.On("CancelPreload").Return()
+	sut := NewStationController(c, &mocks.TrackReporter{}, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetching := make(chan struct{})
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Run(func(_ mock.Arguments) {
+		close(fetching)
+		<-ctx.Done()
+	}).Return(pandora.Fragment{}, context.Canceled)
+
+	sut.SwitchStations(s)
+	<-sut.StationChanged()
+	go sut.Play(ctx)
+	<-fetching
+
+	checked := make(chan struct{})
+	go func() {
+		_, _ = sut.SkipsRemaining()
+		close(checked)
+	}()
+
+	select {
+	case <-checked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SkipsRemaining should not wait for pandora")
+	}
+}
+
+func TestStationController_SwitchStations_DoesNotReportSkip(t *testing.T) {
+	s := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Dummy Station Radio",
+	}
+	other := pandora.Station{
+		ID:   uuid.Must(uuid.NewRandom()).String(),
+		Name: "Other Station Radio",
+	}
+
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
+	p := &mocks.Player{}
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+	p.On("CancelPreload").Return()
+	p.On("FadeOut").Return()
+	sut := NewStationController(c, r, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := testutil.MakeTrack()
+	a.AudioUrl = "1"
+	a.AllowSkip = true
+	b := testutil.MakeTrack()
+	b.AudioUrl = "2"
+
+	c.On("GetMoreTracks", mock.Anything, s.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{a}}, nil)
+	c.On("GetMoreTracks", mock.Anything, other.ID, api.FragmentRequestReasonNormal).Return(pandora.Fragment{Tracks: []pandora.Track{b}}, nil)
+
+	skips := make(chan string, 1)
+	r.On("ReportSkip", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		skips <- args.Get(1).(pandora.Track).AudioUrl
+	}).Return(nil)
+
+	played := make(chan string, 2)
+	p.On("UpdateStream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		<-sut.NotificationChan()
+		played <- args.String(0)
+	})
+	p.On("DoneChan").Return(nil)
+
+	expectPlayed := func(expected string) {
+		select {
+		case url := <-played:
+			require.Equal(t, expected, url)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for %s to play", expected)
+		}
+	}
+
+	sut.SwitchStations(s)
+	<-sut.StationChanged()
+	go sut.Play(ctx)
+	expectPlayed("1")
+
+	sut.SwitchStations(other)
+	<-sut.StationChanged()
+	expectPlayed("2")
+
+	select {
+	case url := <-skips:
+		t.Fatalf("Changing stations should not report a skip for %s", url)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.Equal(t, skipLimit, sut.skips.remaining(s.ID), "Changing stations should not count against the skip limit")
+	c.AssertCalled(t, "RestartStation", other.ID)
+}
+
+func TestStationController_Shuffle(t *testing.T) {
+	stations := []pandora.Station{
+		{ID: uuid.Must(uuid.NewRandom()).String(), Name: "A Radio"},
+		{ID: uuid.Must(uuid.NewRandom()).String(), Name: "B Radio"},
+	}
+
+	c := &mocks.Client{}
+	c.On("RestartStation", mock.Anything).Return()
+	r := &mocks.TrackReporter{}
+	r.On("ReportAudioReceipt", mock.Anything, mock.Anything).Return(nil)
+	p := &mocks.Player{}
+	p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+	p.On("CancelPreload").Return()
+	sut := NewStationController(c, r, p)
+	sut.log = testutil.NopLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, station := range stations {
+		track := testutil.MakeTrack()
+		track.AudioUrl = station.Name
+		track.StationId = ""
+
+		c.On("GetMoreTracks", mock.Anything, station.ID, mock.Anything).Return(pandora.Fragment{Tracks: []pandora.Track{track}}, nil)
+	}
+
+	doneCh := make(chan error, 1)
+	done := make(chan struct{})
+	var played []string
+
+	var doneChRet <-chan error = doneCh
+	p.On("UpdateStream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		msg := <-sut.NotificationChan()
+		require.Equal(t, args.String(0), msg.Station.Name, "Notifications should name the station the track came from")
+		require.Equal(t, msg.Station, sut.StationOf(msg.Track))
+
+		played = append(played, msg.Station.Name)
+		if len(played) == 3 {
+			cancel()
+			close(done)
+		} else {
+			doneCh <- nil
+		}
+	})
+	p.On("DoneChan").Return(doneChRet)
+
+	require.Equal(t, ErrShuffleNeedsStations, sut.Shuffle(stations[:1]))
+	require.NoError(t, sut.Shuffle(stations))
+	require.Equal(t, ShuffleStationID, (<-sut.StationChanged()).ID)
+
+	go sut.Play(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for shuffled tracks")
+	}
+
+	require.Equal(t, []string{"A Radio", "B Radio", "A Radio"}, played)
+}
+
 func stationControllerTestFunc(f func(t *testing.T, c *mocks.Client, sut *StationController)) func(t *testing.T) {
 	return func(t *testing.T) {
 		c := &mocks.Client{}
+		c.On("RestartStation", mock.Anything).Return()
 		p := &mocks.Player{}
-		sut := NewStationController(c, p)
+		p.On("Preload", mock.Anything, mock.Anything, mock.Anything).Return()
+		p.On("CancelPreload").Return()
+		p.On("FadeOut").Return()
+		sut := NewStationController(c, &mocks.TrackReporter{}, p)
 		sut.log = testutil.NopLogger()
 		sut.playing = &pandora.Track{
 			MusicId:   uuid.Must(uuid.NewRandom()).String(),
@@ -93,19 +515,19 @@ func TestStationController_ExplainCurrentTrack(t *testing.T) {
 			Paragraph:   uuid.Must(uuid.NewRandom()).String(),
 		}
 
-		c.On("GetNarrative", mock.Anything, mock.Anything).Return(expected, nil)
+		c.On("GetNarrative", mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
 
-		result, err := sut.ExplainCurrentTrack()
-		c.AssertCalled(t, "GetNarrative", sut.playing.StationId, sut.playing.MusicId)
+		result, err := sut.ExplainCurrentTrack(context.Background())
+		c.AssertCalled(t, "GetNarrative", mock.Anything, sut.playing.StationId, sut.playing.MusicId)
 		require.NoError(t, err)
 		require.Equal(t, expected, result)
 	}))
 
 	t.Run("Pandora Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
-		c.On("GetNarrative", mock.Anything, mock.Anything).
+		c.On("GetNarrative", mock.Anything, mock.Anything, mock.Anything).
 			Return(pandora.Narrative{}, fmt.Errorf("dummy"))
 
-		_, err := sut.ExplainCurrentTrack()
+		_, err := sut.ExplainCurrentTrack(context.Background())
 		require.EqualError(t, err, "dummy")
 	}))
 
@@ -116,11 +538,11 @@ func TestStationController_ExplainCurrentTrack(t *testing.T) {
 			Paragraph:   uuid.Must(uuid.NewRandom()).String(),
 		}
 
-		c.On("GetNarrative", mock.Anything, mock.Anything).Return(expected, nil)
+		c.On("GetNarrative", mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
 
-		_, _ = sut.ExplainCurrentTrack()
-		result, err := sut.ExplainCurrentTrack()
-		c.AssertCalled(t, "GetNarrative", sut.playing.StationId, sut.playing.MusicId)
+		_, _ = sut.ExplainCurrentTrack(context.Background())
+		result, err := sut.ExplainCurrentTrack(context.Background())
+		c.AssertCalled(t, "GetNarrative", mock.Anything, sut.playing.StationId, sut.playing.MusicId)
 		c.AssertNumberOfCalls(t, "GetNarrative", 1)
 		require.NoError(t, err)
 		require.Equal(t, expected, result)
@@ -133,10 +555,10 @@ func TestStationController_ExplainCurrentTrack(t *testing.T) {
 			Paragraph:   uuid.Must(uuid.NewRandom()).String(),
 		}
 
-		c.On("GetNarrative", mock.Anything, mock.Anything).Return(expected, nil)
+		c.On("GetNarrative", mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
 
-		result, err := sut.ExplainCurrentTrack()
-		c.AssertCalled(t, "GetNarrative", sut.playing.StationId, sut.playing.MusicId)
+		result, err := sut.ExplainCurrentTrack(context.Background())
+		c.AssertCalled(t, "GetNarrative", mock.Anything, sut.playing.StationId, sut.playing.MusicId)
 		require.NoError(t, err)
 		require.Equal(t, expected, result)
 
@@ -145,11 +567,544 @@ func TestStationController_ExplainCurrentTrack(t *testing.T) {
 			StationId: uuid.Must(uuid.NewRandom()).String(),
 		}
 
-		result, err = sut.ExplainCurrentTrack()
-		c.AssertCalled(t, "GetNarrative", sut.playing.StationId, sut.playing.MusicId)
+		result, err = sut.ExplainCurrentTrack(context.Background())
+		c.AssertCalled(t, "GetNarrative", mock.Anything, sut.playing.StationId, sut.playing.MusicId)
 		require.NoError(t, err)
 		require.Equal(t, expected, result)
 
 		c.AssertNumberOfCalls(t, "GetNarrative", 2)
 	}))
 }
+
+func TestStationController_RenameStation(t *testing.T) {
+	t.Run("Current Station", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String(), Name: "Old"}
+		sut.station = s
+
+		c.On("RenameStation", mock.Anything, s.ID, "New").Return(nil)
+
+		result, err := sut.RenameStation(context.Background(), s, "New")
+		require.NoError(t, err)
+		require.Equal(t, "New", result.Name)
+		require.Equal(t, "New", sut.CurrentStation().Name)
+		require.Equal(t, "New", (<-sut.StationChanged()).Name)
+	}))
+
+	t.Run("Other Station", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String(), Name: "Old"}
+
+		c.On("RenameStation", mock.Anything, s.ID, "New").Return(nil)
+
+		result, err := sut.RenameStation(context.Background(), s, "New")
+		require.NoError(t, err)
+		require.Equal(t, "New", result.Name)
+		require.Equal(t, noStationSelected, sut.CurrentStation())
+		require.Len(t, sut.StationChanged(), 0)
+	}))
+
+	t.Run("Pandora Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String(), Name: "Old"}
+		sut.station = s
+
+		c.On("RenameStation", mock.Anything, s.ID, "New").Return(fmt.Errorf("dummy"))
+
+		result, err := sut.RenameStation(context.Background(), s, "New")
+		require.EqualError(t, err, "dummy")
+		require.Equal(t, "Old", result.Name)
+		require.Equal(t, "Old", sut.CurrentStation().Name)
+	}))
+}
+
+func TestStationController_DeleteStation(t *testing.T) {
+	t.Run("Valid", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+
+		c.On("DeleteStation", mock.Anything, s.ID).Return(nil)
+
+		require.NoError(t, sut.DeleteStation(context.Background(), s))
+		c.AssertCalled(t, "DeleteStation", mock.Anything, s.ID)
+	}))
+
+	t.Run("Current Station", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.station = s
+
+		require.Equal(t, ErrDeleteCurrentStation, sut.DeleteStation(context.Background(), s))
+		c.AssertNotCalled(t, "DeleteStation", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Shuffled Station", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.mix = []pandora.Station{{ID: uuid.Must(uuid.NewRandom()).String()}, s}
+
+		require.Equal(t, ErrDeleteCurrentStation, sut.DeleteStation(context.Background(), s))
+		c.AssertNotCalled(t, "DeleteStation", mock.Anything, mock.Anything)
+	}))
+}
+
+func TestStationController_CreateStationFromTrack(t *testing.T) {
+	t.Run("Valid", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		expected := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.playing.PandoraId = "TR:1234"
+		sut.playing.AllowStartStationFromTrack = true
+
+		c.On("CreateStation", mock.Anything, "TR:1234").Return(expected, nil)
+
+		result, err := sut.CreateStationFromTrack(context.Background(), sut.playing)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	}))
+
+	t.Run("Not Allowed", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.AllowStartStationFromTrack = false
+
+		_, err := sut.CreateStationFromTrack(context.Background(), sut.playing)
+		require.Equal(t, ErrCannotStartStationFromTrack, err)
+		c.AssertNotCalled(t, "CreateStation", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Track Changed", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		expected := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		confirmed := sut.playing
+		confirmed.PandoraId = "TR:1234"
+		confirmed.AllowStartStationFromTrack = true
+		sut.playing = &pandora.Track{PandoraId: "TR:5678", AllowStartStationFromTrack: true}
+
+		c.On("CreateStation", mock.Anything, "TR:1234").Return(expected, nil)
+
+		result, err := sut.CreateStationFromTrack(context.Background(), confirmed)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	}))
+
+	t.Run("Nothing Playing", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		_, err := sut.CreateStationFromTrack(context.Background(), nil)
+		require.Equal(t, ErrCannotStartStationFromTrack, err)
+	}))
+}
+
+func TestStationController_Seeds(t *testing.T) {
+	t.Run("Seed From Track With Seed ID", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.playing.PandoraId = "TR:1"
+
+		c.On("AddSeed", mock.Anything, s.ID, "TR:1").Return(pandora.StationSeed{PandoraId: "TR:1"}, nil)
+
+		_, err := sut.AddSeedFromTrack(context.Background(), s, sut.playing, pandora.SearchResultTypeTrack)
+		require.NoError(t, err)
+		c.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Seed From Track Searches", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.playing.ArtistName = "Dummy Artist"
+		sut.playing.ArtistMusicId = "R1234"
+
+		c.On("Search", mock.Anything, "Dummy Artist").Return(pandora.SearchResults{
+			Artists: []pandora.SearchResult{{PandoraId: "AR:2", Name: "Dummy Artist Jr"}, {PandoraId: "AR:1", Name: "Dummy Artist"}},
+		}, nil)
+		c.On("AddSeed", mock.Anything, s.ID, "AR:1").Return(pandora.StationSeed{PandoraId: "AR:1"}, nil)
+
+		seed, err := sut.AddSeedFromTrack(context.Background(), s, sut.playing, pandora.SearchResultTypeArtist)
+		require.NoError(t, err)
+		require.Equal(t, "AR:1", seed.PandoraId)
+	}))
+
+	t.Run("Seed From Track Not Found", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.playing.SongTitle = "Dummy Song"
+		sut.playing.ArtistName = "Dummy Artist"
+
+		c.On("Search", mock.Anything, "Dummy Song Dummy Artist").Return(pandora.SearchResults{
+			Tracks: []pandora.SearchResult{{PandoraId: "TR:2", Name: "Dummy Song", ArtistName: "Cover Band"}},
+		}, nil)
+
+		_, err := sut.AddSeedFromTrack(context.Background(), s, sut.playing, pandora.SearchResultTypeTrack)
+		require.True(t, errors.Is(err, ErrNoSeedForTrack))
+		c.AssertNotCalled(t, "AddSeed", mock.Anything, mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Add Seed Clears Queue", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		expected := pandora.StationSeed{SeedId: "seed", PandoraId: "AR:1"}
+		sut.station = s
+		sut.queue = []pandora.Track{testutil.MakeTrack()}
+
+		c.On("AddSeed", mock.Anything, s.ID, "AR:1").Return(expected, nil)
+
+		seed, err := sut.AddSeed(context.Background(), s, "AR:1")
+		require.NoError(t, err)
+		require.Equal(t, expected, seed)
+		require.Empty(t, sut.UpNext())
+	}))
+
+	t.Run("Remove Seed From Other Station", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.queue = []pandora.Track{testutil.MakeTrack()}
+
+		c.On("RemoveSeed", mock.Anything, s.ID, "seed").Return(nil)
+
+		require.NoError(t, sut.RemoveSeed(context.Background(), s, pandora.StationSeed{SeedId: "seed"}))
+		require.Len(t, sut.UpNext(), 1)
+	}))
+
+	t.Run("Pandora Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		s := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.station = s
+		sut.queue = []pandora.Track{testutil.MakeTrack()}
+
+		c.On("RemoveSeed", mock.Anything, s.ID, "seed").Return(fmt.Errorf("dummy"))
+
+		require.EqualError(t, sut.RemoveSeed(context.Background(), s, pandora.StationSeed{SeedId: "seed"}), "dummy")
+		require.Len(t, sut.UpNext(), 1)
+	}))
+}
+
+func TestStationController_ProvideFeedback(t *testing.T) {
+	t.Run("Does Not Hold Lock", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		release := make(chan struct{})
+		rating := make(chan struct{})
+		c.On("AddFeedback", mock.Anything, mock.Anything, true).Run(func(_ mock.Arguments) {
+			close(rating)
+			<-release
+		}).Return(pandora.Feedback{}, nil)
+
+		done := make(chan error)
+		go func() {
+			done <- sut.ProvideFeedback(context.Background(), pandora.TrackRatingLike)
+		}()
+		<-rating
+
+		checked := make(chan struct{})
+		go func() {
+			_, _ = sut.SkipsRemaining()
+			close(checked)
+		}()
+
+		select {
+		case <-checked:
+		case <-time.After(5 * time.Second):
+			t.Fatal("SkipsRemaining should not wait for pandora")
+		}
+
+		close(release)
+		require.NoError(t, <-done)
+		require.EqualValues(t, pandora.TrackRatingLike, sut.playing.Rating)
+	}))
+
+	t.Run("Track Changed", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		banned := sut.playing
+		banned.AllowSkip = true
+		next := testutil.MakeTrack()
+		next.AllowSkip = true
+		c.On("AddFeedback", mock.Anything, mock.Anything, false).Run(func(_ mock.Arguments) {
+			sut.stationLock.Lock()
+			sut.playing = &next
+			sut.stationLock.Unlock()
+		}).Return(pandora.Feedback{}, nil)
+
+		require.NoError(t, sut.ProvideFeedback(context.Background(), pandora.TrackRatingBan))
+		require.EqualValues(t, pandora.TrackRatingBan, banned.Rating)
+		require.Equal(t, skipLimit, sut.skips.remaining(sut.originOf(&next).ID))
+	}))
+
+	t.Run("Nothing Playing", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing = nil
+
+		require.True(t, errors.Is(sut.ProvideFeedback(context.Background(), pandora.TrackRatingLike), ErrNothingPlaying))
+		c.AssertNotCalled(t, "AddFeedback", mock.Anything, mock.Anything, mock.Anything)
+	}))
+}
+
+func TestStationController_UndoLastFeedback(t *testing.T) {
+	t.Run("Valid", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		feedback := pandora.Feedback{ID: uuid.Must(uuid.NewRandom()).String(), IsPositive: true}
+		c.On("AddFeedback", mock.Anything, sut.playing.TrackToken, true).Return(feedback, nil)
+		c.On("DeleteFeedback", mock.Anything, feedback.ID).Return(nil)
+
+		require.NoError(t, sut.ProvideFeedback(context.Background(), pandora.TrackRatingLike))
+		require.EqualValues(t, pandora.TrackRatingLike, sut.playing.Rating)
+
+		track, err := sut.UndoLastFeedback(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, sut.playing, track)
+		require.EqualValues(t, pandora.TrackRatingNeutral, track.Rating)
+
+		_, err = sut.UndoLastFeedback(context.Background())
+		require.Equal(t, ErrNoFeedbackToUndo, err)
+	}))
+
+	t.Run("Nothing To Undo", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		_, err := sut.UndoLastFeedback(context.Background())
+		require.Equal(t, ErrNoFeedbackToUndo, err)
+		c.AssertNotCalled(t, "DeleteFeedback", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Pandora Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		feedback := pandora.Feedback{ID: uuid.Must(uuid.NewRandom()).String(), IsPositive: true}
+		c.On("AddFeedback", mock.Anything, sut.playing.TrackToken, true).Return(feedback, nil)
+		c.On("DeleteFeedback", mock.Anything, feedback.ID).Return(fmt.Errorf("dummy"))
+
+		require.NoError(t, sut.ProvideFeedback(context.Background(), pandora.TrackRatingLike))
+
+		_, err := sut.UndoLastFeedback(context.Background())
+		require.EqualError(t, err, "dummy")
+		require.EqualValues(t, pandora.TrackRatingLike, sut.playing.Rating)
+	}))
+
+	t.Run("Deleted From Browser", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		feedback := pandora.Feedback{ID: uuid.Must(uuid.NewRandom()).String(), IsPositive: true}
+		c.On("AddFeedback", mock.Anything, sut.playing.TrackToken, true).Return(feedback, nil)
+		c.On("DeleteFeedback", mock.Anything, feedback.ID).Return(nil)
+
+		require.NoError(t, sut.ProvideFeedback(context.Background(), pandora.TrackRatingLike))
+		require.NoError(t, sut.DeleteFeedback(context.Background(), feedback))
+		require.EqualValues(t, pandora.TrackRatingNeutral, sut.playing.Rating)
+
+		_, err := sut.UndoLastFeedback(context.Background())
+		require.Equal(t, ErrNoFeedbackToUndo, err)
+	}))
+}
+
+func TestStationController_Skip(t *testing.T) {
+	t.Run("Limit Reached", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.AllowSkip = true
+
+		for i := skipLimit; i > 0; i-- {
+			remaining, err := sut.SkipsRemaining()
+			require.NoError(t, err)
+			require.Equal(t, i, remaining)
+
+			require.NoError(t, sut.Skip())
+		}
+
+		remaining, err := sut.SkipsRemaining()
+		require.Equal(t, 0, remaining)
+		require.True(t, errors.Is(err, ErrSkipLimitReached))
+		require.True(t, errors.Is(sut.Skip(), ErrSkipLimitReached))
+	}))
+
+	t.Run("Not Allowed", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		require.True(t, errors.Is(sut.Skip(), ErrSkipNotAllowed))
+
+		remaining, _ := sut.SkipsRemaining()
+		require.Equal(t, skipLimit, remaining)
+	}))
+
+	t.Run("Without Limit", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.AllowSkipTrackWithoutLimit = true
+
+		for i := 0; i <= skipLimit; i++ {
+			require.NoError(t, sut.Skip())
+		}
+
+		remaining, err := sut.SkipsRemaining()
+		require.NoError(t, err)
+		require.Equal(t, skipLimit, remaining)
+	}))
+
+	t.Run("Binge Skipping", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.AllowSkip = true
+		sut.bingeSkipping[sut.station.ID] = true
+
+		require.True(t, errors.Is(sut.Skip(), ErrSkipLimitReached))
+	}))
+
+	t.Run("Ban Keeps Playing At Limit", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.AllowSkip = true
+		for i := 0; i < skipLimit; i++ {
+			sut.skips.record(sut.station.ID)
+		}
+
+		c.On("AddFeedback", mock.Anything, mock.Anything, false).Return(pandora.Feedback{}, nil)
+
+		require.NoError(t, sut.ProvideFeedback(context.Background(), pandora.TrackRatingBan))
+		require.EqualValues(t, pandora.TrackRatingBan, sut.playing.Rating)
+	}))
+
+	t.Run("Ban Drops Queued Copies", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		other := pandora.Track{MusicId: uuid.Must(uuid.NewRandom()).String()}
+		sut.queue = []pandora.Track{*sut.playing, other}
+
+		c.On("AddFeedback", mock.Anything, mock.Anything, false).Return(pandora.Feedback{}, nil)
+
+		require.NoError(t, sut.ProvideFeedback(context.Background(), pandora.TrackRatingBan))
+		require.Equal(t, []pandora.Track{other}, sut.UpNext())
+	}))
+
+	t.Run("Shuffled Stations Tracked Separately", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		origin := pandora.Station{ID: sut.playing.StationId}
+		other := pandora.Station{ID: uuid.Must(uuid.NewRandom()).String()}
+		sut.station = pandora.Station{ID: ShuffleStationID}
+		sut.mix = []pandora.Station{origin, other}
+		sut.playing.AllowSkip = true
+
+		require.NoError(t, sut.Skip())
+		require.Equal(t, skipLimit-1, sut.skips.remaining(origin.ID))
+		require.Equal(t, skipLimit, sut.skips.remaining(other.ID))
+	}))
+}
+
+func TestStationController_Bookmarks(t *testing.T) {
+	t.Run("Song", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.TrackToken = "track"
+		expected := pandora.Bookmark{ID: "bookmark", Type: pandora.BookmarkTypeSong}
+		c.On("BookmarkSong", mock.Anything, "track").Return(expected, nil)
+
+		bookmark, err := sut.BookmarkCurrentSong(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, bookmark)
+		require.True(t, sut.playing.IsBookmarked)
+	}))
+
+	t.Run("Artist", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing.TrackToken = "track"
+		expected := pandora.Bookmark{ID: "bookmark", Type: pandora.BookmarkTypeArtist}
+		c.On("BookmarkArtist", mock.Anything, "track").Return(expected, nil)
+
+		bookmark, err := sut.BookmarkCurrentArtist(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, bookmark)
+		require.False(t, sut.playing.IsBookmarked)
+	}))
+
+	t.Run("Pandora Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		c.On("BookmarkSong", mock.Anything, mock.Anything).Return(pandora.Bookmark{}, fmt.Errorf("dummy"))
+
+		_, err := sut.BookmarkCurrentSong(context.Background())
+		require.EqualError(t, err, "dummy")
+		require.False(t, sut.playing.IsBookmarked)
+	}))
+
+	t.Run("Does Not Hold Lock", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		release := make(chan struct{})
+		bookmarking := make(chan struct{})
+		c.On("BookmarkSong", mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+			close(bookmarking)
+			<-release
+		}).Return(pandora.Bookmark{}, nil)
+
+		done := make(chan struct{})
+		go func() {
+			_, _ = sut.BookmarkCurrentSong(context.Background())
+			close(done)
+		}()
+		<-bookmarking
+
+		checked := make(chan struct{})
+		go func() {
+			_, _ = sut.SkipsRemaining()
+			close(checked)
+		}()
+
+		select {
+		case <-checked:
+		case <-time.After(5 * time.Second):
+			t.Fatal("SkipsRemaining should not wait for pandora")
+		}
+
+		close(release)
+		<-done
+		require.True(t, sut.playing.IsBookmarked)
+	}))
+
+	t.Run("Nothing Playing", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		sut.playing = nil
+
+		_, err := sut.BookmarkCurrentSong(context.Background())
+		require.True(t, errors.Is(err, ErrNothingPlaying))
+
+		_, err = sut.BookmarkCurrentArtist(context.Background())
+		require.True(t, errors.Is(err, ErrNothingPlaying))
+	}))
+}
+
+func TestStationController_GenreCategories(t *testing.T) {
+	expected := []pandora.GenreCategory{
+		{Name: "Rock", Stations: []pandora.GenreStation{{PandoraId: "G1", Name: "Classic Rock"}}},
+	}
+
+	t.Run("Without Cache", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		c.On("GetGenreCategories", mock.Anything).Return(expected, nil)
+
+		for i := 0; i < 2; i++ {
+			categories, err := sut.GenreCategories(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, expected, categories)
+		}
+
+		c.AssertNumberOfCalls(t, "GetGenreCategories", 2)
+	}))
+
+	t.Run("Cached", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		dir, err := ioutil.TempDir("", "mousiki")
+		require.NoError(t, err)
+		defer func() {
+			_ = os.RemoveAll(dir)
+		}()
+
+		sut.CacheGenresAt(filepath.Join(dir, "genres.json"))
+		c.On("GetGenreCategories", mock.Anything).Return(expected, nil)
+
+		for i := 0; i < 2; i++ {
+			categories, err := sut.GenreCategories(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, expected, categories)
+		}
+
+		c.AssertNumberOfCalls(t, "GetGenreCategories", 1)
+	}))
+
+	t.Run("Stale Cache", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		dir, err := ioutil.TempDir("", "mousiki")
+		require.NoError(t, err)
+		defer func() {
+			_ = os.RemoveAll(dir)
+		}()
+
+		path := filepath.Join(dir, "genres.json")
+		require.NoError(t, SaveGenreCache(path, GenreCache{
+			FetchedAt:  time.Now().Add(-genreCacheTTL),
+			Categories: []pandora.GenreCategory{{Name: "Old"}},
+		}))
+
+		sut.CacheGenresAt(path)
+		c.On("GetGenreCategories", mock.Anything).Return(expected, nil)
+
+		categories, err := sut.GenreCategories(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, categories)
+
+		cached, err := LoadGenreCache(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, cached.Categories)
+	}))
+
+	t.Run("Stale Cache Used On Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		dir, err := ioutil.TempDir("", "mousiki")
+		require.NoError(t, err)
+		defer func() {
+			_ = os.RemoveAll(dir)
+		}()
+
+		path := filepath.Join(dir, "genres.json")
+		require.NoError(t, SaveGenreCache(path, GenreCache{
+			FetchedAt:  time.Now().Add(-genreCacheTTL),
+			Categories: expected,
+		}))
+
+		sut.CacheGenresAt(path)
+		c.On("GetGenreCategories", mock.Anything).Return(nil, fmt.Errorf("dummy"))
+
+		categories, err := sut.GenreCategories(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, categories)
+	}))
+
+	t.Run("Pandora Error", stationControllerTestFunc(func(t *testing.T, c *mocks.Client, sut *StationController) {
+		c.On("GetGenreCategories", mock.Anything).Return(nil, fmt.Errorf("dummy"))
+
+		_, err := sut.GenreCategories(context.Background())
+		require.EqualError(t, err, "dummy")
+	}))
+}
@@ -10,13 +10,15 @@ import (
 )
 
 func New(ctx context.Context, cancelFunc context.CancelFunc, player audio.Player, controller *mousiki.StationController) *cview.Application {
-	root := MainWindow(cancelFunc, player, controller)
+	root := MainWindow(ctx, cancelFunc, player, controller)
 	app := cview.NewApplication().SetRoot(root, true)
 	app.SetInputCapture(root.HandleKey(app))
 	logrus.SetOutput(root)
 
 	app.SetAfterResizeFunc(root.OnResize)
-	app.QueueUpdateDraw(root.ShowStationPicker)
+	app.QueueUpdateDraw(func() {
+		root.ShowStationPicker(app)
+	})
 
 	go root.SyncData(ctx, app)
 	return app
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell"
+	"github.com/nlowe/mousiki/mousiki"
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/sirupsen/logrus"
+	"gitlab.com/tslocum/cview"
+)
+
+const bookmarkBrowserPageName = "bookmarkBrowser"
+
+// bookmarkBrowser lists the bookmarked artists and songs and creates a
+// station from the one that is picked
+type bookmarkBrowser struct {
+	*CenteredModal
+	list *cview.List
+
+	// bookmarks holds the bookmark for each item in list
+	bookmarks []pandora.Bookmark
+
+	controller *mousiki.StationController
+	pager      *cview.Pages
+
+	ctx       context.Context
+	app       *cview.Application
+	onCreated func(station pandora.Station)
+
+	log logrus.FieldLogger
+}
+
+func NewBookmarkBrowserForPager(pager *cview.Pages, controller *mousiki.StationController) *bookmarkBrowser {
+	result := &bookmarkBrowser{
+		list: cview.NewList(),
+
+		controller: controller,
+		pager:      pager,
+
+		log: logrus.WithField("prefix", bookmarkBrowserPageName),
+	}
+
+	result.list.ShowSecondaryText(false).
+		SetSelectedFunc(func(i int, _, _ string, _ rune) {
+			result.createStation(i)
+		}).
+		SetTitle(" Bookmarks ").
+		SetBorder(true)
+
+	result.CenteredModal = NewCenteredModal(result.list)
+
+	pager.AddPage(bookmarkBrowserPageName, result, true, false)
+	return result
+}
+
+// Open shows the bookmark browser. onCreated is called from the UI goroutine
+// with the station created from the selected bookmark.
+func (b *bookmarkBrowser) Open(ctx context.Context, app *cview.Application, onCreated func(station pandora.Station)) {
+	if page, _ := b.pager.GetFrontPage(); page == bookmarkBrowserPageName {
+		return
+	}
+
+	b.ctx = ctx
+	b.app = app
+	b.onCreated = onCreated
+
+	b.list.Clear()
+	b.bookmarks = nil
+
+	// Re-add the page so it is drawn on top of the page that opened it
+	b.pager.AddPage(bookmarkBrowserPageName, b, true, true)
+
+	b.log.Info("Fetching Bookmarks...")
+	go func() {
+		bookmarks, err := b.controller.Bookmarks(ctx)
+		if err != nil {
+			b.log.WithError(err).Error(describeError(err, "Failed to fetch bookmarks"))
+			return
+		}
+
+		app.QueueUpdateDraw(func() {
+			b.list.Clear()
+			b.bookmarks = bookmarks.All()
+			for _, bookmark := range b.bookmarks {
+				b.list.AddItem(cview.Escape(bookmark.String()), bookmark.ID, 0, nil)
+			}
+		})
+	}()
+}
+
+func (b *bookmarkBrowser) Close() {
+	if page, _ := b.pager.GetFrontPage(); page != bookmarkBrowserPageName {
+		return
+	}
+
+	b.pager.HidePage(bookmarkBrowserPageName)
+}
+
+func (b *bookmarkBrowser) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
+	if ev.Key() == tcell.KeyEscape || (ev.Key() == tcell.KeyRune && ev.Rune() == 'm') {
+		b.Close()
+		return nil
+	}
+
+	return ev
+}
+
+func (b *bookmarkBrowser) createStation(i int) {
+	if i < 0 || i >= len(b.bookmarks) {
+		return
+	}
+
+	bookmark := b.bookmarks[i]
+	b.log.WithFields(logrus.Fields{
+		"bookmark":  bookmark.String(),
+		"pandoraId": bookmark.PandoraId,
+	}).Info("Creating Station")
+
+	ctx, app, onCreated := b.ctx, b.app, b.onCreated
+	go func() {
+		station, err := b.controller.CreateStation(ctx, bookmark.PandoraId)
+		if err != nil {
+			b.log.WithError(err).Error(describeError(err, "Failed to create station"))
+			return
+		}
+
+		app.QueueUpdateDraw(func() {
+			b.Close()
+			onCreated(station)
+		})
+	}()
+}
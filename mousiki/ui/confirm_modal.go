@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell"
+	"gitlab.com/tslocum/cview"
+)
+
+const confirmModalPageName = "confirmModal"
+
+// confirmModal asks the user to confirm a destructive action
+type confirmModal struct {
+	*CenteredModal
+	form *cview.Form
+
+	pager *cview.Pages
+}
+
+func NewConfirmModalForPager(pager *cview.Pages) *confirmModal {
+	result := &confirmModal{
+		form:  cview.NewForm(),
+		pager: pager,
+	}
+
+	result.form.SetButtonsAlign(cview.AlignCenter).
+		SetBorder(true)
+
+	result.CenteredModal = NewCenteredModal(result.form)
+
+	pager.AddPage(confirmModalPageName, result, true, false)
+	return result
+}
+
+// Open shows the modal with the specified question, calling onConfirm if the
+// user accepts. The modal defaults to declining so that a stray enter does
+// not confirm the action.
+func (c *confirmModal) Open(question string, onConfirm func()) {
+	c.form.ClearButtons().
+		AddButton("Yes", func() {
+			c.Close()
+			onConfirm()
+		}).
+		AddButton("No", c.Close).
+		SetFocus(1)
+
+	c.form.SetTitle(" " + question + " ")
+	// Re-add the page so it is drawn on top of the page that opened it
+	c.pager.AddPage(confirmModalPageName, c, true, true)
+}
+
+func (c *confirmModal) Close() {
+	if page, _ := c.pager.GetFrontPage(); page != confirmModalPageName {
+		return
+	}
+
+	c.pager.HidePage(confirmModalPageName)
+}
+
+func (c *confirmModal) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
+	if ev.Key() == tcell.KeyEscape {
+		c.Close()
+
+		return nil
+	}
+
+	return ev
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/nlowe/mousiki/mousiki"
+	"github.com/nlowe/mousiki/pandora/api"
+)
+
+var friendlyErrors = []struct {
+	err     error
+	message string
+}{
+	{api.ErrMaintenanceMode, "Pandora is down for maintenance, try again later"},
+	{api.ErrLicensingRestrictions, "Pandora is not available in your country"},
+	{api.ErrReadOnlyMode, "Pandora is in read-only mode, changes can't be saved right now"},
+	{api.ErrInvalidAuth, "Your session has expired, restart mousiki to log in again"},
+	{api.ErrInvalidCredentials, "Invalid username or password"},
+	{api.ErrListenerNotAuthorized, "Your account is not allowed to do that"},
+	{api.ErrUserNotAuthorized, "Your account is not allowed to do that"},
+	{api.ErrMaxStationsReached, "You have reached the maximum number of stations"},
+	{api.ErrStationDoesNotExist, "That station no longer exists"},
+	{api.ErrCallNotAllowed, "Pandora does not allow that right now"},
+	{api.ErrPlaylistExceeded, "You have listened to this station too much recently, try another station"},
+	{api.ErrRateLimited, "Pandora is rate limiting requests, slow down"},
+	{mousiki.ErrNoFeedbackToUndo, "There is no rating to undo"},
+	{mousiki.ErrCannotStartStationFromTrack, "Pandora doesn't allow creating a station from this song"},
+	{mousiki.ErrDeleteCurrentStation, "Switch to a different station before deleting this one"},
+	{mousiki.ErrNothingPlaying, "Nothing is playing yet"},
+	{mousiki.ErrSkipNotAllowed, "Pandora doesn't allow skipping this song"},
+	{mousiki.ErrSkipLimitReached, "You've reached the skip limit for this station, try again later"},
+	{mousiki.ErrNoSeedForTrack, "Pandora couldn't find that to add as a seed"},
+	{mousiki.ErrShuffleNeedsStations, "Mark at least two stations with [M] to shuffle them"},
+}
+
+// describeError returns a human-readable explanation of err if it is a known
+// pandora error, or fallback otherwise
+func describeError(err error, fallback string) string {
+	for _, f := range friendlyErrors {
+		if errors.Is(err, f.err) {
+			return f.message
+		}
+	}
+
+	return fallback
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/nlowe/mousiki/mousiki"
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/sirupsen/logrus"
+	"gitlab.com/tslocum/cview"
+)
+
+const feedbackBrowserPageName = "feedbackBrowser"
+
+var errFeedbackBrowserReopened = errors.New("feedback browser was re-opened")
+
+// feedbackBrowser lists the tracks that were loved or banned on a station
+type feedbackBrowser struct {
+	*CenteredModal
+	list *cview.List
+
+	// feedback holds the feedback for each item in list
+	feedback []pandora.Feedback
+
+	controller *mousiki.StationController
+	pager      *cview.Pages
+	confirm    *confirmModal
+
+	ctx     context.Context
+	app     *cview.Application
+	loading chan struct{}
+
+	log logrus.FieldLogger
+}
+
+func NewFeedbackBrowserForPager(pager *cview.Pages, controller *mousiki.StationController, confirm *confirmModal) *feedbackBrowser {
+	result := &feedbackBrowser{
+		list: cview.NewList(),
+
+		controller: controller,
+		pager:      pager,
+		confirm:    confirm,
+
+		log: logrus.WithField("prefix", feedbackBrowserPageName),
+	}
+
+	result.list.ShowSecondaryText(false).
+		SetBorder(true)
+
+	result.CenteredModal = NewCenteredModal(result.list)
+
+	pager.AddPage(feedbackBrowserPageName, result, true, false)
+	return result
+}
+
+func (f *feedbackBrowser) Open(ctx context.Context, app *cview.Application, station pandora.Station) {
+	if page, _ := f.pager.GetFrontPage(); page == feedbackBrowserPageName {
+		return
+	}
+
+	// Stop adding feedback from a previous load, if any
+	if f.loading != nil {
+		close(f.loading)
+	}
+
+	loading := make(chan struct{})
+	f.loading = loading
+	f.ctx = ctx
+	f.app = app
+
+	f.list.Clear()
+	f.feedback = nil
+	f.list.SetTitle(fmt.Sprintf(" Feedback - %s ", cview.Escape(station.Name)))
+
+	// Re-add the page so it is drawn on top of the page that opened it
+	f.pager.AddPage(feedbackBrowserPageName, f, true, true)
+
+	f.log.Info("Fetching Feedback...")
+	go func() {
+		err := f.controller.StationFeedback(ctx, station, func(page []pandora.Feedback) error {
+			select {
+			case <-loading:
+				return errFeedbackBrowserReopened
+			default:
+			}
+
+			app.QueueUpdateDraw(func() {
+				select {
+				case <-loading:
+					return
+				default:
+				}
+
+				for _, feedback := range page {
+					f.list.AddItem(cview.Escape(feedback.String()), feedback.ID, 0, nil)
+					f.feedback = append(f.feedback, feedback)
+				}
+			})
+
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, errFeedbackBrowserReopened) {
+			f.log.WithError(err).Error(describeError(err, "Failed to fetch station feedback"))
+		}
+	}()
+}
+
+func (f *feedbackBrowser) Close() {
+	if page, _ := f.pager.GetFrontPage(); page != feedbackBrowserPageName {
+		return
+	}
+
+	f.pager.HidePage(feedbackBrowserPageName)
+}
+
+func (f *feedbackBrowser) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
+	if ev.Key() == tcell.KeyEscape || (ev.Key() == tcell.KeyRune && ev.Rune() == 'f') {
+		f.Close()
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'd' {
+		f.deleteSelected()
+		return nil
+	}
+
+	return ev
+}
+
+func (f *feedbackBrowser) deleteSelected() {
+	i := f.list.GetCurrentItem()
+	if i < 0 || i >= len(f.feedback) {
+		return
+	}
+
+	feedback := f.feedback[i]
+	ctx, app := f.ctx, f.app
+	f.confirm.Open(fmt.Sprintf("Remove rating for %s?", feedback.SongTitle), func() {
+		go func() {
+			if err := f.controller.DeleteFeedback(ctx, feedback); err != nil {
+				f.log.WithError(err).Error(describeError(err, "Failed to remove feedback"))
+				return
+			}
+
+			app.QueueUpdateDraw(func() {
+				for i, candidate := range f.feedback {
+					if candidate.ID == feedback.ID {
+						f.feedback = append(f.feedback[:i], f.feedback[i+1:]...)
+						f.list.RemoveItem(i)
+						break
+					}
+				}
+			})
+		}()
+	})
+}
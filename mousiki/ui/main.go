@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -19,22 +20,39 @@ import (
 
 const pageMain = "main"
 
+const (
+	// shortSeek and longSeek are how far the arrow keys and </> seek
+	shortSeek = 10 * time.Second
+	longSeek  = 30 * time.Second
+
+	// volumeStep is how much ( and ) change the volume by, in percent
+	volumeStep = 5
+)
+
 type mainWindow struct {
 	*cview.Pages
 
 	stationPicker  *stationPicker
 	narrativePopup *narrativePopup
+	confirmModal   *confirmModal
+	promptModal    *promptModal
+	searchModal    *searchModal
+	stationDetails *stationDetails
+	feedback       *feedbackBrowser
+	bookmarks      *bookmarkBrowser
 
 	nowPlaying        mousiki.MessageTrackChanged
 	nowPlayingSong    *cview.TextView
 	nowPlayingArtist  *cview.TextView
 	nowPlayingAlbum   *cview.TextView
+	nowPlayingSkips   *cview.TextView
 	nowPlayingWrapper *cview.Grid
 
 	shortcuts *cview.Grid
 
 	progress     *cview.ProgressBar
 	progressText *cview.TextView
+	volumeText   *cview.TextView
 
 	history *cview.TextView
 	upNext  *cview.TextView
@@ -44,11 +62,12 @@ type mainWindow struct {
 
 	quitRequested chan struct{}
 
+	ctx context.Context
 	w   io.Writer
 	log logrus.FieldLogger
 }
 
-func MainWindow(cancelFunc func(), player audio.Player, controller *mousiki.StationController) *mainWindow {
+func MainWindow(ctx context.Context, cancelFunc func(), player audio.Player, controller *mousiki.StationController) *mainWindow {
 	logView := cview.NewTextView().
 		SetDynamicColors(true).
 		ScrollToEnd()
@@ -62,12 +81,14 @@ func MainWindow(cancelFunc func(), player audio.Player, controller *mousiki.Stat
 		nowPlayingSong:   cview.NewTextView().SetDynamicColors(true),
 		nowPlayingArtist: cview.NewTextView().SetDynamicColors(true),
 		nowPlayingAlbum:  cview.NewTextView().SetDynamicColors(true),
+		nowPlayingSkips:  cview.NewTextView().SetDynamicColors(true),
 
 		shortcuts: cview.NewGrid().SetRows(-1).
-			SetColumns(-1, -1, 25, -1, -1, -1, -1),
+			SetColumns(-1, -1, 25, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1),
 
 		progress:     cview.NewProgressBar(),
 		progressText: cview.NewTextView().SetTextAlign(cview.AlignRight),
+		volumeText:   cview.NewTextView().SetDynamicColors(true).SetTextAlign(cview.AlignRight),
 
 		history: cview.NewTextView().SetDynamicColors(true).SetWordWrap(true),
 		upNext:  cview.NewTextView().SetDynamicColors(true).SetWordWrap(true),
@@ -77,6 +98,7 @@ func MainWindow(cancelFunc func(), player audio.Player, controller *mousiki.Stat
 
 		quitRequested: make(chan struct{}),
 
+		ctx: ctx,
 		w:   cview.ANSIWriter(logView),
 		log: logrus.WithField("prefix", "ui"),
 	}
@@ -84,8 +106,14 @@ func MainWindow(cancelFunc func(), player audio.Player, controller *mousiki.Stat
 	grid := cview.NewGrid()
 
 	root.AddPage(pageMain, grid, true, true)
-	root.stationPicker = NewStationPickerForPager(cancelFunc, root.Pages, controller)
+	root.confirmModal = NewConfirmModalForPager(root.Pages)
+	root.promptModal = NewPromptModalForPager(root.Pages)
+	root.stationPicker = NewStationPickerForPager(cancelFunc, root.Pages, controller, root.confirmModal, root.promptModal)
 	root.narrativePopup = NewNarrativePopupForPager(cancelFunc, root.Pages, controller)
+	root.searchModal = NewSearchModalForPager(root.Pages, controller)
+	root.stationDetails = NewStationDetailsForPager(root.Pages, controller, root.confirmModal)
+	root.feedback = NewFeedbackBrowserForPager(root.Pages, controller, root.confirmModal)
+	root.bookmarks = NewBookmarkBrowserForPager(root.Pages, controller)
 
 	root.history.ScrollToEnd().
 		SetDrawFunc(func(_ tcell.Screen, x, y, w, h int) (rx int, ry int, rw int, rh int) {
@@ -104,18 +132,23 @@ func MainWindow(cancelFunc func(), player audio.Player, controller *mousiki.Stat
 	root.nowPlayingSong.SetDynamicColors(true).SetTextAlign(cview.AlignCenter).SetText("?")
 	root.nowPlayingArtist.SetDynamicColors(true).SetTextAlign(cview.AlignCenter).SetText("?")
 	root.nowPlayingAlbum.SetDynamicColors(true).SetTextAlign(cview.AlignCenter).SetText("?")
+	root.nowPlayingSkips.SetTextAlign(cview.AlignRight)
 
 	nowPlaying := cview.NewGrid().
 		SetRows(1, 1, 1).
 		SetColumns(-2, -6, -2).
 		AddItem(root.nowPlayingSong, 0, 1, 1, 1, 0, 0, false).
 		AddItem(root.nowPlayingArtist, 1, 1, 1, 1, 0, 0, false).
-		AddItem(root.nowPlayingAlbum, 2, 1, 1, 1, 0, 0, false)
+		AddItem(root.nowPlayingAlbum, 2, 1, 1, 1, 0, 0, false).
+		AddItem(root.nowPlayingSkips, 0, 2, 1, 1, 0, 0, false)
 
 	transport := cview.NewGrid().
-		SetColumns(0, 13).
+		SetColumns(0, 13, 10).
 		AddItem(root.progress, 0, 0, 1, 1, 0, 0, false).
-		AddItem(root.progressText, 0, 1, 1, 1, 0, 0, false)
+		AddItem(root.progressText, 0, 1, 1, 1, 0, 0, false).
+		AddItem(root.volumeText, 0, 2, 1, 1, 0, 0, false)
+
+	root.volumeText.SetText(root.formatVolume())
 
 	root.nowPlayingWrapper = cview.NewGrid().
 		SetRows(3, 1).
@@ -155,18 +188,76 @@ func (w *mainWindow) updateShortcuts() {
 			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[N] Next"), 0, 4, 1, 1, 0, 0, false).
 			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[-] Ban Song"), 0, 5, 1, 1, 0, 0, false).
 			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[T] Tired Of Song"), 0, 6, 1, 1, 0, 0, false).
-			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[+] Love Song"), 0, 7, 1, 1, 0, 0, false)
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[+] Love Song"), 0, 7, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[S] New Station"), 0, 8, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[C] Station From Song"), 0, 9, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[I] Station Details"), 0, 10, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[F] Feedback"), 0, 11, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[U] Undo Rating"), 0, 12, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[B/A] Bookmark Song/Artist"), 0, 13, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[M] Bookmarks"), 0, 14, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[←/→ </>] Seek 10s/30s"), 0, 15, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[( / ) V] Volume / Mute"), 0, 16, 1, 1, 0, 0, false)
 	} else if page == stationPickerPageName {
 		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Q/ESC] Quit"), 0, 0, 1, 2, 0, 0, false).
-			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Space/Enter] Change Station"), 0, 2, 1, 2, 0, 0, false)
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Space/Enter] Change Station"), 0, 2, 1, 2, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[R] Rename"), 0, 4, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[D] Delete"), 0, 5, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[S] New Station"), 0, 6, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[I] Details"), 0, 7, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Tab] Stations / Genres"), 0, 8, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[M] Mark For Shuffle"), 0, 9, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[X] Shuffle Marked"), 0, 10, 1, 1, 0, 0, false)
 	} else if page == narrativePopupPageName {
 		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[ESC/E] Close"), 0, 2, 1, 1, 0, 0, false)
+	} else if page == stationDetailsPageName {
+		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[ESC/I] Close"), 0, 1, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[D] Remove Seed"), 0, 2, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[A] Seed Current Artist"), 0, 3, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[S] Seed Current Song"), 0, 4, 1, 1, 0, 0, false)
+	} else if page == feedbackBrowserPageName {
+		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[ESC/F] Close"), 0, 1, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[D] Remove Rating"), 0, 2, 1, 1, 0, 0, false)
+	} else if page == bookmarkBrowserPageName {
+		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[ESC/M] Close"), 0, 1, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Enter] Create Station"), 0, 2, 1, 1, 0, 0, false)
+	} else if page == searchModalPageName {
+		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Tab] Switch Focus"), 0, 1, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Enter] Create Station"), 0, 2, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[ESC] Cancel"), 0, 3, 1, 1, 0, 0, false)
+	} else if page == confirmModalPageName || page == promptModalPageName {
+		w.shortcuts.AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Tab] Next Field"), 0, 1, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[Enter] Select"), 0, 2, 1, 1, 0, 0, false).
+			AddItem(cview.NewTextView().SetTextAlign(cview.AlignCenter).SetWrap(false).SetText("[ESC] Cancel"), 0, 3, 1, 1, 0, 0, false)
 	}
 }
 
 func (w *mainWindow) HandleKey(app *cview.Application) func(ev *tcell.EventKey) *tcell.EventKey {
 	return func(ev *tcell.EventKey) *tcell.EventKey {
-		if page, _ := w.GetFrontPage(); page == stationPickerPageName {
+		if page, _ := w.GetFrontPage(); page == confirmModalPageName {
+			return w.confirmModal.HandleKey(ev)
+		} else if page == promptModalPageName {
+			return w.promptModal.HandleKey(ev)
+		} else if page == searchModalPageName {
+			return w.searchModal.HandleKey(ev)
+		} else if page == stationDetailsPageName {
+			return w.stationDetails.HandleKey(ev)
+		} else if page == feedbackBrowserPageName {
+			return w.feedback.HandleKey(ev)
+		} else if page == bookmarkBrowserPageName {
+			return w.bookmarks.HandleKey(ev)
+		} else if page == stationPickerPageName {
+			if ev.Key() == tcell.KeyRune && ev.Rune() == 's' {
+				w.ShowSearchModal(app)
+				return nil
+			} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'i' {
+				if station, ok := w.stationPicker.selected(); ok {
+					w.stationDetails.Open(w.ctx, app, station)
+				}
+
+				return nil
+			}
+
 			return w.stationPicker.HandleKey(ev)
 		} else if page == narrativePopupPageName {
 			return w.narrativePopup.HandleKey(ev)
@@ -190,28 +281,84 @@ func (w *mainWindow) HandleKey(app *cview.Application) func(ev *tcell.EventKey)
 			w.log.Warn("Forcing re-draw")
 			app.ForceDraw()
 		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'n' {
-			w.controller.Skip()
+			if err := w.controller.Skip(); err != nil {
+				w.log.WithError(err).Warn(describeError(err, "Failed to skip track"))
+			}
+
+			w.updateSkips(app)
 		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'q' {
 			close(w.quitRequested)
 		} else if ev.Key() == tcell.KeyEscape {
-			w.ShowStationPicker()
+			w.ShowStationPicker(app)
 		} else if ev.Key() == tcell.KeyRune && ev.Rune() == '+' {
-			if err := w.controller.ProvideFeedback(pandora.TrackRatingLike); err != nil {
-				w.log.WithError(err).Error("Failed to add feedback")
-			}
-
-			// Update NowPlaying with the same message to pick up the feedback
-			w.updateNowPlaying(app, w.nowPlaying)
+			w.provideFeedback(app, pandora.TrackRatingLike)
 		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 't' {
-			if err := w.controller.ProvideFeedback(pandora.TrackRatingTired); err != nil {
-				w.log.WithError(err).Error("Failed to add feedback")
-			}
+			w.provideFeedback(app, pandora.TrackRatingTired)
 		} else if ev.Key() == tcell.KeyRune && ev.Rune() == '-' {
-			if err := w.controller.ProvideFeedback(pandora.TrackRatingBan); err != nil {
-				w.log.WithError(err).Error("Failed to add feedback")
-			}
+			w.provideFeedback(app, pandora.TrackRatingBan)
 		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'e' {
 			w.ShowNarrativePopup()
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 's' {
+			w.ShowSearchModal(app)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'c' {
+			w.createStationFromCurrentTrack(app)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'i' {
+			if station, ok := w.playingStation(); ok {
+				w.stationDetails.Open(w.ctx, app, station)
+			}
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'f' {
+			if station, ok := w.playingStation(); ok {
+				w.feedback.Open(w.ctx, app, station)
+			}
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'b' {
+			go func() {
+				if _, err := w.controller.BookmarkCurrentSong(w.ctx); err != nil {
+					w.log.WithError(err).Error(describeError(err, "Failed to bookmark song"))
+					return
+				}
+
+				w.log.Info("Bookmarked song")
+				w.refreshNowPlaying(app)
+			}()
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'a' {
+			go func() {
+				if _, err := w.controller.BookmarkCurrentArtist(w.ctx); err != nil {
+					w.log.WithError(err).Error(describeError(err, "Failed to bookmark artist"))
+					return
+				}
+
+				w.log.Info("Bookmarked artist")
+			}()
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'm' {
+			w.bookmarks.Open(w.ctx, app, w.switchToNewStation)
+		} else if ev.Key() == tcell.KeyLeft {
+			w.player.SeekBy(-shortSeek)
+		} else if ev.Key() == tcell.KeyRight {
+			w.player.SeekBy(shortSeek)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == '<' {
+			w.player.SeekBy(-longSeek)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == '>' {
+			w.player.SeekBy(longSeek)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == '(' {
+			w.player.SetVolume(w.player.Volume() - volumeStep)
+			w.updateVolume(app)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == ')' {
+			w.player.SetVolume(w.player.Volume() + volumeStep)
+			w.updateVolume(app)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'v' {
+			w.player.SetMuted(!w.player.IsMuted())
+			w.updateVolume(app)
+		} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'u' {
+			go func() {
+				track, err := w.controller.UndoLastFeedback(w.ctx)
+				if err != nil {
+					w.log.WithError(err).Error(describeError(err, "Failed to undo feedback"))
+					return
+				}
+
+				w.log.WithField("track", track.SongTitle).Info("Removed rating")
+				w.refreshNowPlaying(app)
+			}()
 		} else {
 			return ev
 		}
@@ -237,14 +384,83 @@ func (w *mainWindow) OnResize(width, height int) {
 
 	// TODO: Can we grow this automatically based on explanation length?
 	w.narrativePopup.Resize(intClamp(width/2, 40, 120), intClamp(height/4, 10, 16))
+
+	w.feedback.Resize(intClamp(width/2, 40, 100), intClamp(height/2, 10, 30))
+	w.stationDetails.Resize(intClamp(width/2, 40, 100), intClamp(height/2, 10, 30))
+	w.bookmarks.Resize(intClamp(width/2, 40, 100), intClamp(height/2, 10, 30))
+	w.searchModal.Resize(intClamp(width/2, 40, 100), intClamp(height/2, 10, 30))
+	w.confirmModal.Resize(intClamp(width/3, 30, 60), 5)
+	w.promptModal.Resize(intClamp(width/2, 40, 80), 7)
 }
 
-func (w *mainWindow) ShowStationPicker() {
-	w.stationPicker.Open()
+func (w *mainWindow) ShowStationPicker(app *cview.Application) {
+	w.stationPicker.Open(w.ctx, app)
 }
 
 func (w *mainWindow) ShowNarrativePopup() {
-	w.narrativePopup.Open()
+	w.narrativePopup.Open(w.ctx)
+}
+
+func (w *mainWindow) ShowSearchModal(app *cview.Application) {
+	w.searchModal.Open(w.ctx, app, w.switchToNewStation)
+}
+
+// playingStation returns the station the current track is playing from. While
+// shuffling, this is the station in the mix the track came from.
+func (w *mainWindow) playingStation() (pandora.Station, bool) {
+	station := w.controller.StationOf(w.controller.NowPlaying())
+	if station.ID == mousiki.NoStationSelected || station.ID == mousiki.ShuffleStationID {
+		return pandora.Station{}, false
+	}
+
+	return station, true
+}
+
+func (w *mainWindow) switchToNewStation(station pandora.Station) {
+	w.stationPicker.Close()
+	w.controller.SwitchStations(station)
+}
+
+// provideFeedback rates the current track in the background since pandora can
+// take a while to respond
+func (w *mainWindow) provideFeedback(app *cview.Application, f pandora.TrackRating) {
+	go func() {
+		if err := w.controller.ProvideFeedback(w.ctx, f); err != nil {
+			w.log.WithError(err).Error(describeError(err, "Failed to add feedback"))
+			return
+		}
+
+		w.refreshNowPlaying(app)
+	}()
+}
+
+func (w *mainWindow) createStationFromCurrentTrack(app *cview.Application) {
+	track := w.controller.NowPlaying()
+	if track == nil {
+		w.log.Debug("No Track is playing")
+		return
+	}
+
+	if !track.AllowStartStationFromTrack {
+		w.log.Error(describeError(mousiki.ErrCannotStartStationFromTrack, "Failed to create station"))
+		return
+	}
+
+	w.confirmModal.Open(fmt.Sprintf("Create a station from %s?", track.SongTitle), func() {
+		go func() {
+			// Use the track that was confirmed, even if a different one is
+			// playing by now
+			station, err := w.controller.CreateStationFromTrack(w.ctx, track)
+			if err != nil {
+				w.log.WithError(err).Error(describeError(err, "Failed to create station"))
+				return
+			}
+
+			app.QueueUpdateDraw(func() {
+				w.switchToNewStation(station)
+			})
+		}()
+	})
 }
 
 func (w *mainWindow) SyncData(ctx context.Context, app *cview.Application) {
@@ -309,27 +525,75 @@ func (w *mainWindow) updateProgress(app *cview.Application, p audio.PlaybackProg
 	})
 }
 
+func (w *mainWindow) updateVolume(app *cview.Application) {
+	app.QueueUpdateDraw(func() {
+		w.volumeText.SetText(w.formatVolume())
+	})
+}
+
+// formatVolume describes the player's master volume
+func (w *mainWindow) formatVolume() string {
+	if w.player.IsMuted() {
+		return "[red]Muted[-]"
+	}
+
+	return fmt.Sprintf("Vol %d%%", w.player.Volume())
+}
+
 func (w *mainWindow) updateNowPlaying(app *cview.Application, m mousiki.MessageTrackChanged) {
 	app.QueueUpdateDraw(func() {
-		w.nowPlayingSong.SetText(FormatTrackTitle(m.Track))
-		w.nowPlayingArtist.SetText(FormatTrackArtist(m.Track))
-		w.nowPlayingAlbum.SetText(FormatTrackAlbum(m.Track))
+		w.showNowPlaying(m)
+	})
+}
 
-		if w.nowPlaying.Track != nil && w.nowPlaying.Track != m.Track {
-			_, _ = w.history.Write([]byte("\n" + FormatTrack(w.nowPlaying.Track, w.nowPlaying.Station)))
-		}
+// refreshNowPlaying redraws the current track to pick up changes to it like
+// feedback or bookmarks. It's safe to call from outside the UI goroutine.
+func (w *mainWindow) refreshNowPlaying(app *cview.Application) {
+	app.QueueUpdateDraw(func() {
+		w.showNowPlaying(w.nowPlaying)
+	})
+}
+
+// showNowPlaying must be called from the UI goroutine
+func (w *mainWindow) showNowPlaying(m mousiki.MessageTrackChanged) {
+	w.nowPlayingSong.SetText(FormatTrackTitle(m.Track))
+	w.nowPlayingArtist.SetText(FormatTrackArtist(m.Track))
+	w.nowPlayingAlbum.SetText(FormatTrackAlbum(m.Track))
+	w.nowPlayingSkips.SetText(w.formatSkips(m.Track))
+
+	if w.nowPlaying.Track != nil && w.nowPlaying.Track != m.Track {
+		_, _ = w.history.Write([]byte("\n" + FormatTrack(w.nowPlaying.Track, w.nowPlaying.Station)))
+	}
 
-		w.nowPlaying = m
+	w.nowPlaying = m
+}
+
+func (w *mainWindow) updateSkips(app *cview.Application) {
+	app.QueueUpdateDraw(func() {
+		w.nowPlayingSkips.SetText(w.formatSkips(w.nowPlaying.Track))
 	})
 }
 
+// formatSkips describes how many tracks can still be skipped on the current
+// station, or why the current track can't be skipped
+func (w *mainWindow) formatSkips(t *pandora.Track) string {
+	remaining, err := w.controller.SkipsRemaining()
+	if errors.Is(err, mousiki.ErrSkipNotAllowed) {
+		return "[red]Can't skip[-]"
+	} else if errors.Is(err, mousiki.ErrSkipLimitReached) {
+		return "[red]Skip limit reached[-]"
+	} else if t != nil && t.AllowSkipTrackWithoutLimit {
+		return "[green]Unlimited skips[-]"
+	}
+
+	return fmt.Sprintf("[green]%d skips left[-]", remaining)
+}
+
 func (w *mainWindow) updateUpNext(app *cview.Application) {
 	app.QueueUpdateDraw(func() {
-		station := w.controller.CurrentStation()
-
 		buff := strings.Builder{}
 		for _, t := range w.controller.UpNext() {
-			_, _ = fmt.Fprintln(&buff, FormatTrack(&t, station))
+			_, _ = fmt.Fprintln(&buff, FormatTrack(&t, w.controller.StationOf(&t)))
 		}
 
 		w.upNext.SetText(strings.TrimSpace(buff.String()))
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"context"
+
 	"github.com/gdamore/tcell"
 	"github.com/nlowe/mousiki/mousiki"
 	"github.com/sirupsen/logrus"
@@ -42,7 +44,7 @@ func NewNarrativePopupForPager(cancelFunc func(), pager *cview.Pages, controller
 	return result
 }
 
-func (n *narrativePopup) Open() {
+func (n *narrativePopup) Open(ctx context.Context) {
 	if page, _ := n.pager.GetFrontPage(); page == narrativePopupPageName {
 		return
 	}
@@ -53,9 +55,9 @@ func (n *narrativePopup) Open() {
 	}
 
 	n.log.Info("Fetching Track Narrative")
-	narrative, err := n.controller.ExplainCurrentTrack()
+	narrative, err := n.controller.ExplainCurrentTrack(ctx)
 	if err != nil {
-		n.log.WithError(err).Errorf("Failed to explain current track")
+		n.log.WithError(err).Error(describeError(err, "Failed to explain current track"))
 		return
 	}
 
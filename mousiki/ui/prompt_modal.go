@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"gitlab.com/tslocum/cview"
+)
+
+const promptModalPageName = "promptModal"
+
+// promptModal asks the user for a single line of text
+type promptModal struct {
+	*CenteredModal
+	form  *cview.Form
+	input *cview.InputField
+
+	pager *cview.Pages
+}
+
+func NewPromptModalForPager(pager *cview.Pages) *promptModal {
+	result := &promptModal{
+		form:  cview.NewForm(),
+		input: cview.NewInputField(),
+		pager: pager,
+	}
+
+	result.form.AddFormItem(result.input).
+		SetButtonsAlign(cview.AlignCenter).
+		SetBorder(true)
+
+	result.CenteredModal = NewCenteredModal(result.form)
+
+	pager.AddPage(promptModalPageName, result, true, false)
+	return result
+}
+
+// Open shows the modal with the input pre-filled with value, calling
+// onSubmit with the new value if the user saves a non-empty value
+func (p *promptModal) Open(title, label, value string, onSubmit func(value string)) {
+	submit := func() {
+		text := strings.TrimSpace(p.input.GetText())
+		if text == "" {
+			return
+		}
+
+		p.Close()
+		onSubmit(text)
+	}
+
+	p.input.SetLabel(label + " ").
+		SetText(value).
+		SetDoneFunc(func(key tcell.Key) {
+			if key == tcell.KeyEnter {
+				submit()
+			}
+		})
+
+	p.form.ClearButtons().
+		AddButton("Save", submit).
+		AddButton("Cancel", p.Close).
+		SetFocus(0)
+
+	p.form.SetTitle(" " + title + " ")
+	// Re-add the page so it is drawn on top of the page that opened it
+	p.pager.AddPage(promptModalPageName, p, true, true)
+}
+
+func (p *promptModal) Close() {
+	if page, _ := p.pager.GetFrontPage(); page != promptModalPageName {
+		return
+	}
+
+	p.pager.HidePage(promptModalPageName)
+}
+
+func (p *promptModal) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
+	if ev.Key() == tcell.KeyEscape {
+		p.Close()
+
+		return nil
+	}
+
+	return ev
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/nlowe/mousiki/mousiki"
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/sirupsen/logrus"
+	"gitlab.com/tslocum/cview"
+)
+
+const searchModalPageName = "searchModal"
+
+// searchDelay is how long to wait after the last key press before searching,
+// so we don't search for every character typed
+const searchDelay = 300 * time.Millisecond
+
+type searchModal struct {
+	*CenteredModal
+	root    *cview.Flex
+	input   *cview.InputField
+	results *cview.List
+
+	controller *mousiki.StationController
+	pager      *cview.Pages
+
+	ctx       context.Context
+	app       *cview.Application
+	onCreated func(station pandora.Station)
+
+	// pending is the timer for the next search. generation is incremented
+	// every time the query changes so results for stale queries are ignored.
+	// Both are only accessed from the UI goroutine.
+	pending    *time.Timer
+	generation int
+
+	log logrus.FieldLogger
+}
+
+func NewSearchModalForPager(pager *cview.Pages, controller *mousiki.StationController) *searchModal {
+	result := &searchModal{
+		root:    cview.NewFlex(),
+		input:   cview.NewInputField(),
+		results: cview.NewList(),
+
+		controller: controller,
+		pager:      pager,
+
+		log: logrus.WithField("prefix", searchModalPageName),
+	}
+
+	result.input.SetLabel("Search: ").
+		SetPlaceholder("Artist, song, or genre").
+		SetChangedFunc(result.queueSearch)
+
+	result.results.ShowSecondaryText(false)
+
+	result.root.SetDirection(cview.FlexRow).
+		AddItem(result.input, 1, 0, true).
+		AddItem(result.results, 0, 1, false)
+
+	result.root.SetTitle(" New Station ").
+		SetBorder(true).
+		SetBorderPadding(0, 0, 1, 1)
+
+	result.CenteredModal = NewCenteredModal(result.root)
+
+	pager.AddPage(searchModalPageName, result, true, false)
+	return result
+}
+
+// Open shows the search modal. onCreated is called from the UI goroutine with
+// the station created from the selected result.
+func (s *searchModal) Open(ctx context.Context, app *cview.Application, onCreated func(station pandora.Station)) {
+	if page, _ := s.pager.GetFrontPage(); page == searchModalPageName {
+		return
+	}
+
+	s.ctx = ctx
+	s.app = app
+	s.onCreated = onCreated
+
+	s.input.SetText("")
+	s.results.Clear()
+
+	// Re-add the page so it is drawn on top of the page that opened it
+	s.pager.AddPage(searchModalPageName, s, true, true)
+	app.SetFocus(s.input)
+}
+
+func (s *searchModal) Close() {
+	if page, _ := s.pager.GetFrontPage(); page != searchModalPageName {
+		return
+	}
+
+	if s.pending != nil {
+		s.pending.Stop()
+	}
+
+	s.generation++
+	s.pager.HidePage(searchModalPageName)
+}
+
+func (s *searchModal) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		s.Close()
+		return nil
+	case tcell.KeyTab, tcell.KeyBacktab:
+		if s.app.GetFocus() == s.input {
+			s.app.SetFocus(s.results)
+		} else {
+			s.app.SetFocus(s.input)
+		}
+
+		return nil
+	case tcell.KeyDown:
+		if s.app.GetFocus() == s.input && s.results.GetItemCount() > 0 {
+			s.app.SetFocus(s.results)
+			return nil
+		}
+	}
+
+	return ev
+}
+
+func (s *searchModal) queueSearch(text string) {
+	s.generation++
+	generation := s.generation
+
+	if s.pending != nil {
+		s.pending.Stop()
+	}
+
+	query := strings.TrimSpace(text)
+	if query == "" {
+		s.results.Clear()
+		return
+	}
+
+	ctx, app := s.ctx, s.app
+	s.pending = time.AfterFunc(searchDelay, func() {
+		s.log.WithField("query", query).Debug("Searching")
+		results, err := s.controller.Search(ctx, query)
+		if err != nil {
+			s.log.WithError(err).Error(describeError(err, "Search failed"))
+			return
+		}
+
+		app.QueueUpdateDraw(func() {
+			if generation != s.generation {
+				return
+			}
+
+			s.showResults(results)
+		})
+	})
+}
+
+func (s *searchModal) showResults(results pandora.SearchResults) {
+	s.results.Clear()
+
+	all := results.All()
+	if len(all) == 0 {
+		s.results.AddItem("No results", "", 0, nil)
+		return
+	}
+
+	for _, result := range all {
+		s.results.AddItem(cview.Escape(result.String()), result.PandoraId, 0, s.makeCreateFunction(result))
+	}
+}
+
+func (s *searchModal) makeCreateFunction(result pandora.SearchResult) func() {
+	return func() {
+		s.log.WithFields(logrus.Fields{
+			"name":      result.Name,
+			"pandoraId": result.PandoraId,
+		}).Info("Creating Station")
+
+		ctx, app, onCreated := s.ctx, s.app, s.onCreated
+		go func() {
+			station, err := s.controller.CreateStation(ctx, result.PandoraId)
+			if err != nil {
+				s.log.WithError(err).Error(describeError(err, "Failed to create station"))
+				return
+			}
+
+			app.QueueUpdateDraw(func() {
+				s.Close()
+				onCreated(station)
+			})
+		}()
+	}
+}
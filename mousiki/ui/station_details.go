@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/nlowe/mousiki/mousiki"
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/sirupsen/logrus"
+	"gitlab.com/tslocum/cview"
+)
+
+const stationDetailsPageName = "stationDetails"
+
+type stationDetails struct {
+	*CenteredModal
+	root    *cview.Flex
+	summary *cview.TextView
+	seeds   *cview.List
+
+	controller *mousiki.StationController
+	pager      *cview.Pages
+	confirm    *confirmModal
+
+	ctx     context.Context
+	app     *cview.Application
+	station pandora.Station
+	details pandora.StationDetails
+
+	log logrus.FieldLogger
+}
+
+func NewStationDetailsForPager(pager *cview.Pages, controller *mousiki.StationController, confirm *confirmModal) *stationDetails {
+	result := &stationDetails{
+		root:    cview.NewFlex(),
+		summary: cview.NewTextView().SetDynamicColors(true),
+		seeds:   cview.NewList(),
+
+		controller: controller,
+		pager:      pager,
+		confirm:    confirm,
+
+		log: logrus.WithField("prefix", stationDetailsPageName),
+	}
+
+	result.seeds.ShowSecondaryText(false).
+		SetTitle(" Seeds ").
+		SetBorder(true)
+
+	result.root.SetDirection(cview.FlexRow).
+		AddItem(result.summary, 2, 0, false).
+		AddItem(result.seeds, 0, 1, true)
+
+	result.root.SetBorder(true).
+		SetBorderPadding(0, 0, 1, 1)
+
+	result.CenteredModal = NewCenteredModal(result.root)
+
+	pager.AddPage(stationDetailsPageName, result, true, false)
+	return result
+}
+
+func (d *stationDetails) Open(ctx context.Context, app *cview.Application, station pandora.Station) {
+	if page, _ := d.pager.GetFrontPage(); page == stationDetailsPageName {
+		return
+	}
+
+	d.ctx = ctx
+	d.app = app
+	d.station = station
+	d.details = pandora.StationDetails{}
+
+	d.root.SetTitle(fmt.Sprintf(" %s ", cview.Escape(station.Name)))
+	d.summary.SetText("Loading...")
+	d.seeds.Clear()
+
+	// Re-add the page so it is drawn on top of the page that opened it
+	d.pager.AddPage(stationDetailsPageName, d, true, true)
+	d.refresh()
+}
+
+func (d *stationDetails) Close() {
+	if page, _ := d.pager.GetFrontPage(); page != stationDetailsPageName {
+		return
+	}
+
+	d.pager.HidePage(stationDetailsPageName)
+}
+
+func (d *stationDetails) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
+	if ev.Key() == tcell.KeyEscape || (ev.Key() == tcell.KeyRune && ev.Rune() == 'i') {
+		d.Close()
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'd' {
+		d.removeSelectedSeed()
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'a' {
+		d.addSeedFromNowPlaying(pandora.SearchResultTypeArtist)
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 's' {
+		d.addSeedFromNowPlaying(pandora.SearchResultTypeTrack)
+		return nil
+	}
+
+	return ev
+}
+
+// refresh fetches the station details in the background
+func (d *stationDetails) refresh() {
+	ctx, app, station := d.ctx, d.app, d.station
+	go func() {
+		details, err := d.controller.StationDetails(ctx, station)
+		if err != nil {
+			d.log.WithError(err).Error(describeError(err, "Failed to fetch station details"))
+			return
+		}
+
+		app.QueueUpdateDraw(func() {
+			// Ignore stale details if another station was opened since
+			if d.station.ID != station.ID {
+				return
+			}
+
+			d.details = details
+			d.render()
+		})
+	}()
+}
+
+func (d *stationDetails) render() {
+	createdFrom := "Unknown"
+	if d.details.InitialSeed.Name != "" {
+		createdFrom = d.details.InitialSeed.String()
+	}
+
+	d.summary.SetText(fmt.Sprintf(
+		"[::b]Created From:[::-] %s\n[::b]Feedback:[::-] %d thumbs up, %d thumbs down",
+		cview.Escape(createdFrom),
+		d.details.PositiveFeedbackCount,
+		d.details.NegativeFeedbackCount,
+	))
+
+	current := d.seeds.GetCurrentItem()
+	d.seeds.Clear()
+	for _, seed := range d.details.Seeds {
+		d.seeds.AddItem(cview.Escape(seed.String()), seed.SeedId, 0, nil)
+	}
+
+	if current >= 0 && current < d.seeds.GetItemCount() {
+		d.seeds.SetCurrentItem(current)
+	}
+}
+
+func (d *stationDetails) removeSelectedSeed() {
+	i := d.seeds.GetCurrentItem()
+	if i < 0 || i >= len(d.details.Seeds) {
+		return
+	}
+
+	seed := d.details.Seeds[i]
+	ctx, app, station := d.ctx, d.app, d.station
+	d.confirm.Open(fmt.Sprintf("Remove %s from this station?", seed.Name), func() {
+		go func() {
+			if err := d.controller.RemoveSeed(ctx, station, seed); err != nil {
+				d.log.WithError(err).Error(describeError(err, "Failed to remove seed"))
+				return
+			}
+
+			app.QueueUpdateDraw(func() {
+				if d.station.ID != station.ID {
+					return
+				}
+
+				for i, candidate := range d.details.Seeds {
+					if candidate.SeedId == seed.SeedId {
+						d.details.Seeds = append(d.details.Seeds[:i], d.details.Seeds[i+1:]...)
+						break
+					}
+				}
+
+				d.render()
+			})
+		}()
+	})
+}
+
+// addSeedFromNowPlaying adds the artist or song of the current track as a seed
+// in the background since it may have to be looked up first
+func (d *stationDetails) addSeedFromNowPlaying(kind pandora.SearchResultType) {
+	track := d.controller.NowPlaying()
+	if track == nil {
+		d.log.Error(describeError(mousiki.ErrNothingPlaying, "Failed to add seed"))
+		return
+	}
+
+	ctx, app, station := d.ctx, d.app, d.station
+	go func() {
+		seed, err := d.controller.AddSeedFromTrack(ctx, station, track, kind)
+		if err != nil {
+			d.log.WithError(err).Error(describeError(err, "Failed to add seed"))
+			return
+		}
+
+		app.QueueUpdateDraw(func() {
+			if d.station.ID != station.ID {
+				return
+			}
+
+			d.details.Seeds = append(d.details.Seeds, seed)
+			d.render()
+		})
+	}()
+}
@@ -1,6 +1,10 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/gdamore/tcell"
 	"github.com/nlowe/mousiki/mousiki"
 	"github.com/nlowe/mousiki/pandora"
@@ -8,7 +12,14 @@ import (
 	"gitlab.com/tslocum/cview"
 )
 
-const stationPickerPageName = "stationPicker"
+const (
+	stationPickerPageName = "stationPicker"
+
+	stationPickerStationsView = "stations"
+	stationPickerGenresView   = "genres"
+)
+
+var errStationPickerReopened = errors.New("station picker was re-opened")
 
 const (
 	EscapeActionExit = iota
@@ -19,24 +30,51 @@ type stationPicker struct {
 	*CenteredModal
 	list *cview.List
 
+	// stations holds the station for each item in list
+	stations []pandora.Station
+
+	// marked holds the IDs of the stations picked to shuffle
+	marked map[string]bool
+
+	// The station list and genre catalog are shown as tabs, switched with tab
+	tabs          *cview.TextView
+	views         *cview.Pages
+	genres        *cview.TreeView
+	genresLoaded  bool
+	showingGenres bool
+
 	cancelFunc func()
 
 	controller *mousiki.StationController
 	pager      *cview.Pages
+	confirm    *confirmModal
+	prompt     *promptModal
 
 	EscapeAction int
 
+	ctx     context.Context
+	app     *cview.Application
+	loading chan struct{}
+
 	log logrus.FieldLogger
 }
 
-func NewStationPickerForPager(cancelFunc func(), pager *cview.Pages, controller *mousiki.StationController) *stationPicker {
+func NewStationPickerForPager(cancelFunc func(), pager *cview.Pages, controller *mousiki.StationController, confirm *confirmModal, prompt *promptModal) *stationPicker {
 	root := &stationPicker{
 		list: cview.NewList(),
 
+		marked: map[string]bool{},
+
+		tabs:   cview.NewTextView().SetDynamicColors(true),
+		views:  cview.NewPages(),
+		genres: cview.NewTreeView(),
+
 		cancelFunc: cancelFunc,
 
 		controller: controller,
 		pager:      pager,
+		confirm:    confirm,
+		prompt:     prompt,
 
 		log: logrus.WithField("prefix", stationPickerPageName),
 	}
@@ -45,38 +83,98 @@ func NewStationPickerForPager(cancelFunc func(), pager *cview.Pages, controller
 		SetTitle(" Select Station ").
 		SetBorder(true)
 
-	root.CenteredModal = NewCenteredModal(root.list)
+	root.genres.SetRoot(cview.NewTreeNode("Genres")).
+		SetTopLevel(1).
+		SetTitle(" Browse Genres ").
+		SetBorder(true)
+
+	root.views.AddPage(stationPickerStationsView, root.list, true, true).
+		AddPage(stationPickerGenresView, root.genres, true, false)
+
+	content := cview.NewGrid().
+		SetRows(1, 0).
+		AddItem(root.tabs, 0, 0, 1, 1, 0, 0, false).
+		AddItem(root.views, 1, 0, 1, 1, 0, 0, true)
+
+	root.updateTabs()
+	root.CenteredModal = NewCenteredModal(content)
 
 	pager.AddPage(stationPickerPageName, root, true, false)
 	return root
 }
 
-func (s *stationPicker) Open() {
+func (s *stationPicker) Open(ctx context.Context, app *cview.Application) {
 	if page, _ := s.pager.GetFrontPage(); page == stationPickerPageName {
 		return
 	}
 
-	s.log.Info("Fetching Stations...")
-	stations, err := s.controller.ListStations()
-	if err != nil {
-		s.log.WithError(err).Error("Failed to fetch station list")
-		return
+	// Stop adding stations from a previous load, if any
+	if s.loading != nil {
+		close(s.loading)
 	}
 
+	loading := make(chan struct{})
+	s.loading = loading
+	s.ctx = ctx
+	s.app = app
+
 	currentStation := s.controller.CurrentStation()
 
 	s.list.Clear()
-	for _, station := range stations {
-		shortcut := ' '
-		if currentStation.ID == station.ID {
-			shortcut = '*'
+	s.stations = nil
+	s.marked = map[string]bool{}
+	s.showStations()
+	s.pager.ShowPage(stationPickerPageName)
+
+	s.log.Info("Fetching Stations...")
+	go func() {
+		err := s.controller.StreamStations(ctx, func(page []pandora.Station) error {
+			select {
+			case <-loading:
+				return errStationPickerReopened
+			default:
+			}
+
+			app.QueueUpdateDraw(func() {
+				select {
+				case <-loading:
+					return
+				default:
+				}
+
+				for _, station := range page {
+					shortcut := ' '
+					if currentStation.ID == station.ID {
+						shortcut = '*'
+					}
+
+					s.log.WithField("name", station.Name).Debug("Found Station")
+					s.list.AddItem(s.itemText(station), station.ID, shortcut, s.makeSwitchFunction(station))
+					s.stations = append(s.stations, station)
+				}
+			})
+
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, errStationPickerReopened) {
+			s.log.WithError(err).Error(describeError(err, "Failed to fetch station list"))
+			return
 		}
 
-		s.log.WithField("name", station.Name).Debug("Found Station")
-		s.list.AddItem(station.Name, station.ID, shortcut, s.makeSwitchFunction(station))
-	}
+		// New accounts don't have any stations yet, help them find some
+		app.QueueUpdateDraw(func() {
+			select {
+			case <-loading:
+				return
+			default:
+			}
 
-	s.pager.ShowPage(stationPickerPageName)
+			if err == nil && len(s.stations) == 0 {
+				s.showGenres()
+			}
+		})
+	}()
 }
 
 func (s *stationPicker) Close() {
@@ -95,14 +193,261 @@ func (s *stationPicker) HandleKey(ev *tcell.EventKey) *tcell.EventKey {
 			s.Close()
 		}
 
+		return nil
+	} else if ev.Key() == tcell.KeyTab {
+		if s.showingGenres {
+			s.showStations()
+		} else {
+			s.showGenres()
+		}
+
+		return nil
+	} else if s.showingGenres {
+		return ev
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'r' {
+		s.renameSelected()
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'd' {
+		s.deleteSelected()
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'm' {
+		s.toggleMarkSelected()
+		return nil
+	} else if ev.Key() == tcell.KeyRune && ev.Rune() == 'x' {
+		s.shuffleMarked()
 		return nil
 	}
 
 	return ev
 }
 
+func (s *stationPicker) updateTabs() {
+	stations, genres := "[black:white] Stations [-:-]", " Browse Genres "
+	if s.showingGenres {
+		stations, genres = " Stations ", "[black:white] Browse Genres [-:-]"
+	}
+
+	s.tabs.SetText(stations + " " + genres)
+}
+
+func (s *stationPicker) showStations() {
+	s.showingGenres = false
+	s.views.SwitchToPage(stationPickerStationsView)
+	s.updateTabs()
+}
+
+func (s *stationPicker) showGenres() {
+	s.showingGenres = true
+	s.views.SwitchToPage(stationPickerGenresView)
+	s.updateTabs()
+
+	if !s.genresLoaded {
+		s.loadGenres()
+	}
+}
+
+func (s *stationPicker) loadGenres() {
+	s.genresLoaded = true
+
+	ctx, app := s.ctx, s.app
+	s.log.Info("Fetching Genre Stations...")
+	go func() {
+		categories, err := s.controller.GenreCategories(ctx)
+		if err != nil {
+			s.log.WithError(err).Error(describeError(err, "Failed to fetch genre stations"))
+
+			app.QueueUpdateDraw(func() {
+				// Try again the next time the tab is opened
+				s.genresLoaded = false
+			})
+
+			return
+		}
+
+		app.QueueUpdateDraw(func() {
+			root := s.genres.GetRoot().ClearChildren()
+			for _, category := range categories {
+				categoryNode := cview.NewTreeNode(cview.Escape(category.Name)).
+					SetColor(tcell.ColorDarkCyan).
+					SetExpanded(false)
+
+				categoryNode.SetSelectedFunc(func() {
+					categoryNode.SetExpanded(!categoryNode.IsExpanded())
+				})
+
+				for _, genre := range category.Stations {
+					genre := genre
+					categoryNode.AddChild(cview.NewTreeNode(cview.Escape(genre.Name)).
+						SetReference(genre).
+						SetSelectedFunc(func() {
+							s.addGenreStation(genre)
+						}))
+				}
+
+				root.AddChild(categoryNode)
+			}
+
+			if children := root.GetChildren(); len(children) > 0 {
+				s.genres.SetCurrentNode(children[0])
+			}
+		})
+	}()
+}
+
+func (s *stationPicker) addGenreStation(genre pandora.GenreStation) {
+	s.confirm.Open(fmt.Sprintf("Add %s to your stations?", genre.Name), func() {
+		go func() {
+			station, err := s.controller.CreateStation(s.ctx, genre.PandoraId)
+			if err != nil {
+				s.log.WithError(err).Error(describeError(err, "Failed to add genre station"))
+				return
+			}
+
+			s.app.QueueUpdateDraw(func() {
+				s.list.AddItem(s.itemText(station), station.ID, ' ', s.makeSwitchFunction(station))
+				s.stations = append(s.stations, station)
+
+				s.showStations()
+				s.list.SetCurrentItem(len(s.stations) - 1)
+			})
+		}()
+	})
+}
+
+func (s *stationPicker) selected() (pandora.Station, bool) {
+	if s.showingGenres {
+		return pandora.Station{}, false
+	}
+
+	i := s.list.GetCurrentItem()
+	if i < 0 || i >= len(s.stations) {
+		return pandora.Station{}, false
+	}
+
+	return s.stations[i], true
+}
+
+func (s *stationPicker) indexOf(station pandora.Station) int {
+	for i, candidate := range s.stations {
+		if candidate.ID == station.ID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *stationPicker) renameSelected() {
+	station, ok := s.selected()
+	if !ok {
+		return
+	}
+
+	s.prompt.Open("Rename Station", "Name:", station.Name, func(name string) {
+		if name == station.Name {
+			return
+		}
+
+		go func() {
+			renamed, err := s.controller.RenameStation(s.ctx, station, name)
+			if err != nil {
+				s.log.WithError(err).Error(describeError(err, "Failed to rename station"))
+				return
+			}
+
+			s.app.QueueUpdateDraw(func() {
+				if i := s.indexOf(renamed); i >= 0 {
+					s.stations[i] = renamed
+					_, secondary := s.list.GetItemText(i)
+					s.list.SetItemText(i, s.itemText(renamed), secondary)
+				}
+			})
+		}()
+	})
+}
+
+func (s *stationPicker) deleteSelected() {
+	station, ok := s.selected()
+	if !ok {
+		return
+	}
+
+	if station.ID == s.controller.CurrentStation().ID {
+		s.log.Error(describeError(mousiki.ErrDeleteCurrentStation, "Failed to delete station"))
+		return
+	}
+
+	s.confirm.Open(fmt.Sprintf("Delete %s?", station.Name), func() {
+		go func() {
+			if err := s.controller.DeleteStation(s.ctx, station); err != nil {
+				s.log.WithError(err).Error(describeError(err, "Failed to delete station"))
+				return
+			}
+
+			s.app.QueueUpdateDraw(func() {
+				if i := s.indexOf(station); i >= 0 {
+					s.stations = append(s.stations[:i], s.stations[i+1:]...)
+					s.list.RemoveItem(i)
+					delete(s.marked, station.ID)
+				}
+			})
+		}()
+	})
+}
+
+// itemText is the text shown in list for station, marking it if it was picked
+// to shuffle
+func (s *stationPicker) itemText(station pandora.Station) string {
+	if s.marked[station.ID] {
+		return cview.Escape("[+] ") + station.Name
+	}
+
+	return station.Name
+}
+
+func (s *stationPicker) toggleMarkSelected() {
+	station, ok := s.selected()
+	if !ok {
+		return
+	}
+
+	if s.marked[station.ID] {
+		delete(s.marked, station.ID)
+	} else {
+		s.marked[station.ID] = true
+	}
+
+	i := s.list.GetCurrentItem()
+	_, secondary := s.list.GetItemText(i)
+	s.list.SetItemText(i, s.itemText(station), secondary)
+}
+
+// shuffleMarked starts shuffling tracks from the marked stations, in the order
+// they are listed
+func (s *stationPicker) shuffleMarked() {
+	var mix []pandora.Station
+	for _, station := range s.stations {
+		if s.marked[station.ID] {
+			mix = append(mix, station)
+		}
+	}
+
+	if err := s.controller.Shuffle(mix); err != nil {
+		s.log.WithError(err).Error(describeError(err, "Failed to shuffle stations"))
+		return
+	}
+
+	s.log.WithField("stations", len(mix)).Debug("Shuffling Stations")
+	s.Close()
+}
+
 func (s *stationPicker) makeSwitchFunction(station pandora.Station) func() {
 	return func() {
+		// Pick up any changes made to the station since it was listed
+		if i := s.indexOf(station); i >= 0 {
+			station = s.stations[i]
+		}
+
 		s.log.WithFields(logrus.Fields{
 			"name": station.Name,
 			"id":   station.ID,
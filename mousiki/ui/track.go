@@ -13,7 +13,12 @@ var ratingColors = map[pandora.TrackRating]string{
 }
 
 func FormatTrackTitle(t *pandora.Track) string {
-	return fmt.Sprintf("[%s]%s[-]", ratingColors[t.Rating], t.SongTitle)
+	bookmark := ""
+	if t.IsBookmarked {
+		bookmark = " [yellow]\u2605[-]"
+	}
+
+	return fmt.Sprintf("[%s]%s[-]%s", ratingColors[t.Rating], t.SongTitle, bookmark)
 }
 
 func FormatTrackArtist(t *pandora.Track) string {
@@ -0,0 +1,14 @@
+package api
+
+import "github.com/nlowe/mousiki/pandora"
+
+type AddBookmarkRequest struct {
+	TrackToken string `json:"trackToken"`
+}
+
+type GetBookmarksRequest struct{}
+
+type GetBookmarksResponse struct {
+	Artists []pandora.Bookmark `json:"artists"`
+	Songs   []pandora.Bookmark `json:"songs"`
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -17,49 +20,127 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var errNotLoggedIn = errors.New("not logged in")
+
 const (
-	csrfCookieName = "csrftoken"
-	pandoraBase    = "https://www.pandora.com"
-	userAgent      = "Mozilla/5.0 (X11; Datanyze; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/81.0.4044.92 Safari/537.36"
+	csrfCookieName   = "csrftoken"
+	pandoraBase      = "https://www.pandora.com"
+	stationPageSize  = 250
+	searchPageSize   = 20
+	feedbackPageSize = 100
+	defaultUserAgent = "Mozilla/5.0 (X11; Datanyze; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/81.0.4044.92 Safari/537.36"
 )
 
 // Client implements the Pandora REST API defined in https://6xq.net/pandora-apidoc/rest
+//
+// Every call accepts a context.Context that bounds the lifetime of the
+// underlying HTTP request(s)
 type Client interface {
-	Login(username, password string) error
-	GetStations() ([]pandora.Station, error)
-	GetMoreTracks(stationId string) ([]pandora.Track, error)
-	AddFeedback(trackToken string, isPositive bool) error
-	AddTired(trackToken string) error
-	GetNarrative(stationId, musicId string) (pandora.Narrative, error)
+	Login(ctx context.Context, username, password string) error
+	GetStations(ctx context.Context) ([]pandora.Station, error)
+	StreamStations(ctx context.Context, f func(page []pandora.Station) error) error
+	CreateStation(ctx context.Context, pandoraId string) (pandora.Station, error)
+	RenameStation(ctx context.Context, stationId, name string) error
+	DeleteStation(ctx context.Context, stationId string) error
+	Search(ctx context.Context, query string) (pandora.SearchResults, error)
+	GetStationDetails(ctx context.Context, stationId string) (pandora.StationDetails, error)
+	AddSeed(ctx context.Context, stationId, pandoraId string) (pandora.StationSeed, error)
+	RemoveSeed(ctx context.Context, stationId, seedId string) error
+	GetGenreCategories(ctx context.Context) ([]pandora.GenreCategory, error)
+	GetMoreTracks(ctx context.Context, stationId string, reason FragmentRequestReason) (pandora.Fragment, error)
+	// RestartStation forgets where the playlist of a station left off, so the
+	// next fragment fetched for it starts the station over
+	RestartStation(stationId string)
+	AddFeedback(ctx context.Context, trackToken string, isPositive bool) (pandora.Feedback, error)
+	GetStationFeedback(ctx context.Context, stationId string, f func(page []pandora.Feedback) error) error
+	DeleteFeedback(ctx context.Context, feedbackId string) error
+	AddTired(ctx context.Context, trackToken string) error
+	BookmarkSong(ctx context.Context, trackToken string) (pandora.Bookmark, error)
+	BookmarkArtist(ctx context.Context, trackToken string) (pandora.Bookmark, error)
+	GetBookmarks(ctx context.Context) (pandora.Bookmarks, error)
+	GetNarrative(ctx context.Context, stationId, musicId string) (pandora.Narrative, error)
 }
 
 type client struct {
+	authLock  sync.Mutex
+	username  string
 	authToken string
 	csrfToken *http.Cookie
 
-	apiURL  string
-	csrfURL string
-
-	api *http.Client
-	log logrus.FieldLogger
+	// reauth re-runs the login flow that produced the current auth token. It
+	// is set after a successful login and used to transparently recover from
+	// expired auth tokens.
+	reauth     func(ctx context.Context) error
+	reauthLock sync.Mutex
+
+	legacyPartnerID string
+	legacyUserID    string
+	// legacySyncOffset is the difference between pandora's clock, as reported
+	// by the partner sync time, and our own clock
+	legacySyncOffset time.Duration
+
+	apiURL    string
+	csrfURL   string
+	legacyURL string
+
+	// lastTracks holds the ID of the last track returned for each station so
+	// the next fragment can continue where the previous one left off
+	lastTracksLock sync.Mutex
+	lastTracks     map[string]string
+
+	api       *http.Client
+	userAgent string
+	retry     retryConfig
+	now       func() time.Time
+	log       logrus.FieldLogger
 }
 
-func NewClient() *client {
-	return &client{
-		apiURL:  fmt.Sprintf("%s/api", pandoraBase),
-		csrfURL: pandoraBase,
-
-		api: cleanhttp.DefaultClient(),
+// NewClient creates a new pandora client customized by opts. Unless a
+// different http client is provided, each request made by the client is
+// bounded by the request-timeout setting in addition to the context passed to
+// the individual calls.
+func NewClient(opts ...Option) *client {
+	api := cleanhttp.DefaultClient()
+	api.Timeout = viper.GetDuration("request-timeout")
+
+	c := &client{
+		apiURL:    fmt.Sprintf("%s/api", pandoraBase),
+		csrfURL:   pandoraBase,
+		legacyURL: legacyAPIEndpoint,
+
+		lastTracks: map[string]string{},
+
+		api:       api,
+		userAgent: defaultUserAgent,
+		retry: retryConfig{
+			attempts:  defaultRetryAttempts,
+			baseDelay: defaultRetryBaseDelay,
+			maxDelay:  defaultRetryMaxDelay,
+		},
+		now: time.Now,
 		log: logrus.WithField("prefix", "client"),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-func (c *client) updateCSRF() error {
-	resp, err := c.api.Head(c.csrfURL)
+func (c *client) updateCSRF(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.csrfURL, nil)
+	if err != nil {
+		return fmt.Errorf("update csrf: %w", err)
+	}
+
+	resp, err := c.api.Do(req)
 	if err != nil {
 		return fmt.Errorf("update csrf: %w", err)
 	}
 
+	defer mustClose(resp.Body)
+
 	for _, cookie := range resp.Cookies() {
 		if cookie.Name == csrfCookieName {
 			c.csrfToken = cookie
@@ -73,16 +154,16 @@ func (c *client) updateCSRF() error {
 func (c *client) prepare(r *http.Request) error {
 	r.Header.Set("Content-Type", "application/json")
 	r.Header.Set("Accept", "application/json")
-	r.Header.Set("User-Agent", userAgent)
+	r.Header.Set("User-Agent", c.userAgent)
 
-	if c.authToken != "" {
-		r.Header.Set("X-AuthToken", c.authToken)
-	} else if !strings.HasSuffix(r.URL.Path, "/v1/auth/login") {
-		return errors.New("not logged in")
+	if authToken := c.currentAuthToken(); authToken != "" {
+		r.Header.Set("X-AuthToken", authToken)
+	} else if !isLoginPath(r.URL.Path) {
+		return errNotLoggedIn
 	}
 
 	if c.csrfToken == nil {
-		if err := c.updateCSRF(); err != nil {
+		if err := c.updateCSRF(r.Context()); err != nil {
 			return fmt.Errorf("prepare request: %w", err)
 		}
 	}
@@ -93,20 +174,49 @@ func (c *client) prepare(r *http.Request) error {
 	return nil
 }
 
-func (c *client) post(relPath string, payload interface{}) (*http.Response, error) {
+func (c *client) post(ctx context.Context, policy retryPolicy, relPath string, payload interface{}) (*http.Response, error) {
 	buff, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("post: marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequest(
+	send := func(ctx context.Context) (*http.Response, error) {
+		return c.doPost(ctx, relPath, buff)
+	}
+
+	log := c.log.WithField("path", relPath)
+
+	authToken := c.currentAuthToken()
+	if authToken == "" && !isLoginPath(relPath) {
+		// No sense in retrying this
+		return nil, fmt.Errorf("post: %w", errNotLoggedIn)
+	}
+
+	resp, err := c.withRetry(ctx, policy, log, send)
+	if err != nil || isLoginPath(relPath) || !isAuthExpired(resp) {
+		return resp, err
+	}
+
+	log.Info("Auth token expired, logging in again")
+	if err := c.refreshAuth(ctx, authToken); err != nil {
+		log.WithError(err).Warn("Failed to refresh auth token")
+		return resp, nil
+	}
+
+	mustClose(resp.Body)
+	return c.withRetry(ctx, policy, log, send)
+}
+
+func (c *client) doPost(ctx context.Context, relPath string, payload []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		fmt.Sprintf(
 			"%s/%s",
 			c.apiURL,
 			strings.TrimPrefix(relPath, "/"),
 		),
-		bytes.NewReader(buff),
+		bytes.NewReader(payload),
 	)
 
 	if err != nil {
@@ -120,9 +230,48 @@ func (c *client) post(relPath string, payload interface{}) (*http.Response, erro
 	return c.api.Do(req)
 }
 
-func (c *client) Login(username, password string) error {
+func (c *client) currentAuthToken() string {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	return c.authToken
+}
+
+func (c *client) setAuth(username, authToken string, reauth func(ctx context.Context) error) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	c.username = username
+	c.authToken = authToken
+	c.reauth = reauth
+}
+
+// refreshAuth logs in again if the auth token has not changed since staleToken
+// was used. Concurrent requests that fail with the same stale token will only
+// trigger a single login.
+func (c *client) refreshAuth(ctx context.Context, staleToken string) error {
+	c.reauthLock.Lock()
+	defer c.reauthLock.Unlock()
+
+	c.authLock.Lock()
+	authToken, reauth := c.authToken, c.reauth
+	c.authLock.Unlock()
+
+	if authToken != staleToken {
+		// Somebody else already logged in again
+		return nil
+	}
+
+	if reauth == nil {
+		return errors.New("no credentials available to log in again")
+	}
+
+	return reauth(ctx)
+}
+
+func (c *client) Login(ctx context.Context, username, password string) error {
 	c.log.WithField("username", username).Debug("Attempting to log in")
-	resp, err := c.post("/v1/auth/login", &LoginRequest{
+	resp, err := c.post(ctx, retryUnsent, "/v1/auth/login", &LoginRequest{
 		KeepLoggedIn: true,
 		Username:     username,
 		Password:     password,
@@ -142,98 +291,400 @@ func (c *client) Login(username, password string) error {
 		return fmt.Errorf("login: read response: %w", err)
 	}
 
-	c.authToken = payload.AuthToken
+	c.setAuth(username, payload.AuthToken, func(ctx context.Context) error {
+		return c.Login(ctx, username, password)
+	})
 
 	c.log.WithFields(logrus.Fields{"user": payload.Username, "webname": payload.WebName}).Info("Successfully Logged In")
 	return nil
 }
 
-func (c *client) GetStations() ([]pandora.Station, error) {
-	c.log.Debug("Fetching Stations")
-	resp, err := c.post("/v1/station/getStations", &StationRequest{
-		PageSize:   250,
-		StartIndex: 0,
+// GetStations fetches every station for the current user, paging through the
+// results until all stations have been returned
+func (c *client) GetStations(ctx context.Context) ([]pandora.Station, error) {
+	var result []pandora.Station
+	err := c.StreamStations(ctx, func(page []pandora.Station) error {
+		result = append(result, page...)
+		return nil
 	})
 
+	return result, err
+}
+
+// StreamStations fetches stations a page at a time, invoking f with each page
+// as soon as it is received. If f returns an error, paging stops and the error
+// is returned.
+func (c *client) StreamStations(ctx context.Context, f func(page []pandora.Station) error) error {
+	req := StationRequest{
+		PageSize:   stationPageSize,
+		StartIndex: 0,
+	}
+
+	for {
+		c.log.WithFields(logrus.Fields{
+			"startIndex": req.StartIndex,
+			"sortedBy":   req.SortedBy,
+		}).Debug("Fetching Stations")
+
+		payload, err := c.getStationPage(ctx, req)
+		if err != nil {
+			return fmt.Errorf("GetStations: %w", err)
+		}
+
+		if err := f(payload.Stations); err != nil {
+			return err
+		}
+
+		// Keep the sort order consistent between pages so stations don't
+		// shift around while we're paging through them
+		req.SortedBy = payload.SortedBy
+		req.StartIndex = payload.Index + len(payload.Stations)
+
+		if len(payload.Stations) == 0 || req.StartIndex >= payload.TotalStations {
+			return nil
+		}
+	}
+}
+
+func (c *client) getStationPage(ctx context.Context, req StationRequest) (StationResponse, error) {
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/getStations", &req)
 	if err != nil {
-		return nil, fmt.Errorf("GetStations: %w", err)
+		return StationResponse{}, err
 	}
 
 	defer mustClose(resp.Body)
 	if err := checkHttpCode(resp); err != nil {
-		return nil, fmt.Errorf("GetStations: %w", err)
+		return StationResponse{}, err
 	}
 
 	payload := StationResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, fmt.Errorf("GetStations: read response: %w", err)
+		return StationResponse{}, fmt.Errorf("read response: %w", err)
 	}
 
-	// TODO: Paging
-	return payload.Stations, nil
+	return payload, nil
 }
 
-func (c *client) GetMoreTracks(stationId string) ([]pandora.Track, error) {
+// CreateStation creates a new station seeded from the artist or song with the
+// specified pandora ID
+func (c *client) CreateStation(ctx context.Context, pandoraId string) (pandora.Station, error) {
+	c.log.WithField("pandoraId", pandoraId).Debug("Creating Station")
+
+	resp, err := c.post(ctx, retryUnsent, "/v1/station/createStation", &CreateStationRequest{
+		PandoraID: pandoraId,
+	})
+
+	if err != nil {
+		return pandora.Station{}, fmt.Errorf("CreateStation: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return pandora.Station{}, fmt.Errorf("CreateStation: %w", err)
+	}
+
+	payload := pandora.Station{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return pandora.Station{}, fmt.Errorf("CreateStation: read response: %w", err)
+	}
+
+	c.log.WithField("station", payload).Debug("Station Created")
+	return payload, nil
+}
+
+func (c *client) RenameStation(ctx context.Context, stationId, name string) error {
+	c.log.WithFields(logrus.Fields{
+		"station": stationId,
+		"name":    name,
+	}).Debug("Renaming Station")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/updateStation", &UpdateStationRequest{
+		StationID: stationId,
+		Name:      name,
+	})
+
+	if err != nil {
+		return fmt.Errorf("RenameStation: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return fmt.Errorf("RenameStation: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) DeleteStation(ctx context.Context, stationId string) error {
+	c.log.WithField("station", stationId).Debug("Deleting Station")
+
+	resp, err := c.post(ctx, retryUnsent, "/v1/station/removeStation", &RemoveStationRequest{
+		StationID: stationId,
+	})
+
+	if err != nil {
+		return fmt.Errorf("DeleteStation: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return fmt.Errorf("DeleteStation: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) GetStationDetails(ctx context.Context, stationId string) (pandora.StationDetails, error) {
+	c.log.WithField("station", stationId).Debug("Fetching Station Details")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/getStationDetails", &StationDetailsRequest{
+		StationID: stationId,
+	})
+
+	if err != nil {
+		return pandora.StationDetails{}, fmt.Errorf("GetStationDetails: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return pandora.StationDetails{}, fmt.Errorf("GetStationDetails: %w", err)
+	}
+
+	payload := pandora.StationDetails{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return pandora.StationDetails{}, fmt.Errorf("GetStationDetails: read response: %w", err)
+	}
+
+	return payload, nil
+}
+
+// AddSeed adds the artist or track with the specified pandora ID as a seed
+// for the station
+func (c *client) AddSeed(ctx context.Context, stationId, pandoraId string) (pandora.StationSeed, error) {
+	c.log.WithFields(logrus.Fields{
+		"station":   stationId,
+		"pandoraId": pandoraId,
+	}).Debug("Adding Seed")
+
+	resp, err := c.post(ctx, retryUnsent, "/v1/station/addSeed", &AddSeedRequest{
+		StationID: stationId,
+		PandoraID: pandoraId,
+	})
+
+	if err != nil {
+		return pandora.StationSeed{}, fmt.Errorf("AddSeed: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return pandora.StationSeed{}, fmt.Errorf("AddSeed: %w", err)
+	}
+
+	payload := pandora.StationSeed{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return pandora.StationSeed{}, fmt.Errorf("AddSeed: read response: %w", err)
+	}
+
+	return payload, nil
+}
+
+func (c *client) RemoveSeed(ctx context.Context, stationId, seedId string) error {
+	c.log.WithFields(logrus.Fields{
+		"station": stationId,
+		"seed":    seedId,
+	}).Debug("Removing Seed")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/removeSeed", &RemoveSeedRequest{
+		StationID: stationId,
+		SeedID:    seedId,
+	})
+
+	if err != nil {
+		return fmt.Errorf("RemoveSeed: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return fmt.Errorf("RemoveSeed: %w", err)
+	}
+
+	return nil
+}
+
+// GetGenreCategories fetches pandora's catalog of genre stations
+func (c *client) GetGenreCategories(ctx context.Context) ([]pandora.GenreCategory, error) {
+	c.log.Debug("Fetching Genre Stations")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/getGenreStations", &GenreStationsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("GetGenreCategories: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return nil, fmt.Errorf("GetGenreCategories: %w", err)
+	}
+
+	payload := GenreStationsResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("GetGenreCategories: read response: %w", err)
+	}
+
+	return payload.Categories, nil
+}
+
+// Search finds artists, tracks, and genres matching query that a station can
+// be created from
+func (c *client) Search(ctx context.Context, query string) (pandora.SearchResults, error) {
+	c.log.WithField("query", query).Debug("Searching")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v3/sod/search", &SearchRequest{
+		Query: query,
+		Types: []pandora.SearchResultType{
+			pandora.SearchResultTypeArtist,
+			pandora.SearchResultTypeTrack,
+			pandora.SearchResultTypeGenre,
+		},
+		Count:            searchPageSize,
+		Annotate:         true,
+		AnnotationRecipe: "CLASS_OF_2019",
+	})
+
+	if err != nil {
+		return pandora.SearchResults{}, fmt.Errorf("Search: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return pandora.SearchResults{}, fmt.Errorf("Search: %w", err)
+	}
+
+	payload := SearchResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return pandora.SearchResults{}, fmt.Errorf("Search: read response: %w", err)
+	}
+
+	result := pandora.SearchResults{}
+	for _, id := range payload.Results {
+		annotation, ok := payload.Annotations[id]
+		if !ok {
+			continue
+		}
+
+		annotation.PandoraId = id
+		switch annotation.Type {
+		case pandora.SearchResultTypeArtist:
+			result.Artists = append(result.Artists, annotation)
+		case pandora.SearchResultTypeTrack:
+			result.Tracks = append(result.Tracks, annotation)
+		case pandora.SearchResultTypeGenre:
+			result.Genres = append(result.Genres, annotation)
+		}
+	}
+
+	return result, nil
+}
+
+// GetMoreTracks fetches the next fragment of tracks for a station. The first
+// fragment requested for a station marks the start of the station, later
+// fragments continue from the last track of the previous one.
+func (c *client) GetMoreTracks(ctx context.Context, stationId string, reason FragmentRequestReason) (pandora.Fragment, error) {
 	f := pandora.AudioFormat(viper.GetString("audio-format"))
+
+	req := &GetPlaylistFragmentRequest{
+		StationID:             stationId,
+		IsStationStart:        true,
+		FragmentRequestReason: reason,
+		AudioFormat:           f,
+	}
+
+	c.lastTracksLock.Lock()
+	if lastTrack, ok := c.lastTracks[stationId]; ok {
+		req.IsStationStart = false
+		req.StartingAtTrackId = &lastTrack
+	}
+	c.lastTracksLock.Unlock()
+
 	c.log.WithFields(logrus.Fields{
-		"station":     stationId,
-		"audioFormat": f,
+		"station":        stationId,
+		"audioFormat":    f,
+		"reason":         reason,
+		"isStationStart": req.IsStationStart,
 	}).Debug("Fetching more tracks")
 
 	// TODO: What audio formats can we request?
 	// TODO: It doesn't seem to matter what format we request, pandora always gives us aacplus
-	// TODO: Does StartingAtTrackId need to be set when continuing to play a station?
-	resp, err := c.post("/v1/playlist/getFragment", &GetPlaylistFragmentRequest{
-		StationID:             stationId,
-		IsStationStart:        true,
-		FragmentRequestReason: FragmentRequestReasonNormal,
-		AudioFormat:           f,
-	})
+	resp, err := c.post(ctx, retryIdempotent, "/v1/playlist/getFragment", req)
 
 	if err != nil {
-		return nil, fmt.Errorf("GetMoreTracks: %w", err)
+		return pandora.Fragment{}, fmt.Errorf("GetMoreTracks: %w", err)
 	}
 
 	defer mustClose(resp.Body)
 	if err := checkHttpCode(resp); err != nil {
-		return nil, fmt.Errorf("GetMoreTracks: %w", err)
+		return pandora.Fragment{}, fmt.Errorf("GetMoreTracks: %w", err)
 	}
 
 	payload := GetPlaylistFragmentResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, fmt.Errorf("GetMoreTracks: read response: %w", err)
+		return pandora.Fragment{}, fmt.Errorf("GetMoreTracks: read response: %w", err)
 	}
 
 	if payload.IsBingeSkipping {
 		c.log.Warn("Pandora thinks you're skipping tracks too frequently")
 	}
 
-	return payload.Tracks, nil
+	if len(payload.Tracks) > 0 {
+		c.lastTracksLock.Lock()
+		c.lastTracks[stationId] = trackID(payload.Tracks[len(payload.Tracks)-1])
+		c.lastTracksLock.Unlock()
+	}
+
+	return pandora.Fragment{
+		Tracks:          payload.Tracks,
+		IsBingeSkipping: payload.IsBingeSkipping,
+	}, nil
+}
+
+func (c *client) RestartStation(stationId string) {
+	c.lastTracksLock.Lock()
+	defer c.lastTracksLock.Unlock()
+
+	delete(c.lastTracks, stationId)
+}
+
+// trackID is the ID pandora expects in StartingAtTrackId
+func trackID(t pandora.Track) string {
+	if t.TrackKey.TrackID != "" {
+		return t.TrackKey.TrackID
+	}
+
+	return t.MusicId
 }
 
-func (c *client) AddFeedback(trackToken string, isPositive bool) error {
+func (c *client) AddFeedback(ctx context.Context, trackToken string, isPositive bool) (pandora.Feedback, error) {
 	c.log.WithFields(logrus.Fields{
 		"track":      trackToken,
 		"isPositive": isPositive,
 	}).Debug("Adding Feedback")
 
-	resp, err := c.post("/v1/station/addFeedback", &AddFeedbackRequest{
+	resp, err := c.post(ctx, retryUnsent, "/v1/station/addFeedback", &AddFeedbackRequest{
 		TrackToken: trackToken,
 		IsPositive: isPositive,
 	})
 
 	if err != nil {
-		return fmt.Errorf("AddFeedback: %w", err)
+		return pandora.Feedback{}, fmt.Errorf("AddFeedback: %w", err)
 	}
 
 	defer mustClose(resp.Body)
 	if err := checkHttpCode(resp); err != nil {
-		return fmt.Errorf("AddFeedback: %w", err)
+		return pandora.Feedback{}, fmt.Errorf("AddFeedback: %w", err)
 	}
 
 	payload := AddFeedbackResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return fmt.Errorf("AddFeedback: read response: %w", err)
+		return pandora.Feedback{}, fmt.Errorf("AddFeedback: read response: %w", err)
 	}
 
 	c.log.WithFields(logrus.Fields{
@@ -242,15 +693,90 @@ func (c *client) AddFeedback(trackToken string, isPositive bool) error {
 		"isPositive": payload.IsPositive,
 	}).Debug("Feedback Added")
 
+	return pandora.Feedback{
+		ID:         payload.ID,
+		StationId:  payload.StationId,
+		MusicId:    payload.MusicId,
+		PandoraId:  payload.PandoraId,
+		IsPositive: payload.IsPositive,
+	}, nil
+}
+
+// GetStationFeedback fetches the thumbs up and thumbs down given to tracks on
+// a station a page at a time, invoking f with each page as soon as it is
+// received. If f returns an error, paging stops and the error is returned.
+func (c *client) GetStationFeedback(ctx context.Context, stationId string, f func(page []pandora.Feedback) error) error {
+	req := StationFeedbackRequest{
+		StationID: stationId,
+		PageSize:  feedbackPageSize,
+	}
+
+	for {
+		c.log.WithFields(logrus.Fields{
+			"station":    stationId,
+			"startIndex": req.StartIndex,
+		}).Debug("Fetching Station Feedback")
+
+		payload, err := c.getFeedbackPage(ctx, req)
+		if err != nil {
+			return fmt.Errorf("GetStationFeedback: %w", err)
+		}
+
+		if err := f(payload.Feedback); err != nil {
+			return err
+		}
+
+		req.StartIndex += len(payload.Feedback)
+		if len(payload.Feedback) == 0 || req.StartIndex >= payload.Total {
+			return nil
+		}
+	}
+}
+
+func (c *client) getFeedbackPage(ctx context.Context, req StationFeedbackRequest) (StationFeedbackResponse, error) {
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/getStationFeedback", &req)
+	if err != nil {
+		return StationFeedbackResponse{}, err
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return StationFeedbackResponse{}, err
+	}
+
+	payload := StationFeedbackResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return StationFeedbackResponse{}, fmt.Errorf("read response: %w", err)
+	}
+
+	return payload, nil
+}
+
+func (c *client) DeleteFeedback(ctx context.Context, feedbackId string) error {
+	c.log.WithField("feedbackId", feedbackId).Debug("Deleting Feedback")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v1/station/deleteFeedback", &DeleteFeedbackRequest{
+		FeedbackID: feedbackId,
+	})
+
+	if err != nil {
+		return fmt.Errorf("DeleteFeedback: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return fmt.Errorf("DeleteFeedback: %w", err)
+	}
+
 	return nil
 }
 
-func (c *client) AddTired(trackToken string) error {
+func (c *client) AddTired(ctx context.Context, trackToken string) error {
 	c.log.WithFields(logrus.Fields{
 		"track": trackToken,
 	}).Debug("Adding Tired Song")
 
-	resp, err := c.post("/v1/listener/addTiredSong", &AddTiredRequest{
+	resp, err := c.post(ctx, retryUnsent, "/v1/listener/addTiredSong", &AddTiredRequest{
 		TrackToken: trackToken,
 	})
 
@@ -271,13 +797,92 @@ func (c *client) AddTired(trackToken string) error {
 	return nil
 }
 
-func (c *client) GetNarrative(stationId, musicId string) (pandora.Narrative, error) {
+func (c *client) BookmarkSong(ctx context.Context, trackToken string) (pandora.Bookmark, error) {
+	result, err := c.addBookmark(ctx, "/v1/bookmark/addSongBookmark", trackToken, pandora.BookmarkTypeSong)
+	if err != nil {
+		return pandora.Bookmark{}, fmt.Errorf("BookmarkSong: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *client) BookmarkArtist(ctx context.Context, trackToken string) (pandora.Bookmark, error) {
+	result, err := c.addBookmark(ctx, "/v1/bookmark/addArtistBookmark", trackToken, pandora.BookmarkTypeArtist)
+	if err != nil {
+		return pandora.Bookmark{}, fmt.Errorf("BookmarkArtist: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *client) addBookmark(ctx context.Context, relPath, trackToken string, kind pandora.BookmarkType) (pandora.Bookmark, error) {
+	c.log.WithFields(logrus.Fields{
+		"track": trackToken,
+		"type":  kind,
+	}).Debug("Adding Bookmark")
+
+	// Bookmarking the same thing twice is harmless, so this is safe to retry
+	resp, err := c.post(ctx, retryIdempotent, relPath, &AddBookmarkRequest{
+		TrackToken: trackToken,
+	})
+
+	if err != nil {
+		return pandora.Bookmark{}, err
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return pandora.Bookmark{}, err
+	}
+
+	result := pandora.Bookmark{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return pandora.Bookmark{}, fmt.Errorf("read response: %w", err)
+	}
+
+	result.Type = kind
+	return result, nil
+}
+
+func (c *client) GetBookmarks(ctx context.Context) (pandora.Bookmarks, error) {
+	c.log.Debug("Fetching Bookmarks")
+
+	resp, err := c.post(ctx, retryIdempotent, "/v1/bookmark/getBookmarks", &GetBookmarksRequest{})
+	if err != nil {
+		return pandora.Bookmarks{}, fmt.Errorf("GetBookmarks: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return pandora.Bookmarks{}, fmt.Errorf("GetBookmarks: %w", err)
+	}
+
+	payload := GetBookmarksResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return pandora.Bookmarks{}, fmt.Errorf("GetBookmarks: read response: %w", err)
+	}
+
+	for i := range payload.Artists {
+		payload.Artists[i].Type = pandora.BookmarkTypeArtist
+	}
+
+	for i := range payload.Songs {
+		payload.Songs[i].Type = pandora.BookmarkTypeSong
+	}
+
+	return pandora.Bookmarks{
+		Artists: payload.Artists,
+		Songs:   payload.Songs,
+	}, nil
+}
+
+func (c *client) GetNarrative(ctx context.Context, stationId, musicId string) (pandora.Narrative, error) {
 	c.log.WithFields(logrus.Fields{
 		"station": stationId,
 		"track":   musicId,
 	}).Debug("Getting Narrative")
 
-	resp, err := c.post("/v1/playlist/narrative", &NarrativeRequest{
+	resp, err := c.post(ctx, retryIdempotent, "/v1/playlist/narrative", &NarrativeRequest{
 		MusicID:   musicId,
 		StationID: stationId,
 	})
@@ -295,10 +900,26 @@ func (c *client) GetNarrative(stationId, musicId string) (pandora.Narrative, err
 	return payload, json.NewDecoder(resp.Body).Decode(&payload)
 }
 
+func isLoginPath(relPath string) bool {
+	return strings.HasSuffix(relPath, "/v1/auth/login")
+}
+
+// isAuthExpired checks if the response indicates the auth token used to make
+// the request is no longer valid. Pandora signals this either with a 401 or
+// with the INVALID_AUTH_TOKEN error code. The response body is left intact for
+// further inspection.
+func isAuthExpired(r *http.Response) bool {
+	if r.StatusCode == http.StatusOK {
+		return false
+	}
+
+	return errors.Is(peekError(r), ErrInvalidAuth)
+}
+
 func checkHttpCode(r *http.Response) error {
 	if r.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(r.Body)
-		return fmt.Errorf("unexpected result %s:\n%s", r.Status, string(body))
+		return newErrorFromBody(r.StatusCode, r.Status, body)
 	}
 
 	return nil
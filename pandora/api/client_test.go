@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -48,6 +50,8 @@ func setupClientTest(t *testing.T, m *http.ServeMux, authToken string) (*client,
 	c.apiURL = fmt.Sprintf("%s/api", strings.TrimSuffix(sv.URL, "/"))
 	c.csrfURL = fmt.Sprintf("%s/_csrf", strings.TrimSuffix(sv.URL, "/"))
 	c.api = sv.Client()
+	c.retry.baseDelay = time.Millisecond
+	c.retry.maxDelay = 10 * time.Millisecond
 
 	return c, sv, csrfToken
 }
@@ -76,7 +80,7 @@ func TestClient_Login(t *testing.T) {
 		sut, server, _ := setupClientTest(t, m, authToken)
 		defer server.Close()
 
-		require.NoError(t, sut.Login("un", "pw"))
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
 		assert.Equal(t, authToken, sut.authToken)
 	})
 
@@ -91,7 +95,7 @@ func TestClient_Login(t *testing.T) {
 		sut, server, _ := setupClientTest(t, m, authToken)
 		defer server.Close()
 
-		require.EqualError(t, sut.Login("un", "pw"), "login: unexpected result 418 I'm a teapot:\nFoobar")
+		require.EqualError(t, sut.Login(context.Background(), "un", "pw"), "login: unexpected result 418 I'm a teapot:\nFoobar")
 	})
 }
 
@@ -130,23 +134,442 @@ func TestClient_GetStations(t *testing.T) {
 		sut, server, _ := setupClientTest(t, m, authToken)
 		defer server.Close()
 
-		require.NoError(t, sut.Login("un", "pw"))
-		stations, err := sut.GetStations()
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		stations, err := sut.GetStations(context.Background())
 
 		require.NoError(t, err)
 		require.Len(t, stations, 1)
 		require.Equal(t, "Test Station", stations[0].Name)
 	})
 
+	t.Run("Paging", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		expectStationPages(t, m, 3, 5)
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		stations, err := sut.GetStations(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, stations, 5)
+		for i, station := range stations {
+			require.Equal(t, fmt.Sprintf("Station %d", i), station.Name)
+		}
+	})
+
 	t.Run("RequiresLogin", func(t *testing.T) {
 		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
 		defer server.Close()
 
-		_, err := sut.GetStations()
+		_, err := sut.GetStations(context.Background())
 		require.EqualError(t, err, "GetStations: post: not logged in")
 	})
 }
 
+func TestClient_Reauthenticate(t *testing.T) {
+	expired := func(status int, body interface{}) func(*testing.T) {
+		return func(t *testing.T) {
+			authToken := uuid.Must(uuid.NewRandom()).String()
+			logins := 0
+			calls := 0
+
+			m := http.NewServeMux()
+			m.HandleFunc("/api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+				logins++
+				testutil.MarshalResponse(t, http.StatusOK, w, &LoginResponse{AuthToken: authToken})
+			})
+			m.HandleFunc("/api/v1/listener/addTiredSong", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					testutil.MarshalResponse(t, status, w, body)
+					return
+				}
+
+				testutil.MarshalResponse(t, http.StatusOK, w, &AddTiredResponse{})
+			})
+
+			sut, server, _ := setupClientTest(t, m, authToken)
+			defer server.Close()
+
+			require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+			require.NoError(t, sut.AddTired(context.Background(), "foo"))
+			require.Equal(t, 2, logins)
+			require.Equal(t, 2, calls)
+		}
+	}
+
+	t.Run("Unauthorized", expired(http.StatusUnauthorized, map[string]string{}))
+	t.Run("Invalid Auth Token", expired(http.StatusBadRequest, map[string]int{"errorCode": 1001}))
+
+	t.Run("Only Retries Once", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		logins := 0
+		calls := 0
+
+		m := http.NewServeMux()
+		m.HandleFunc("/api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+			logins++
+			testutil.MarshalResponse(t, http.StatusOK, w, &LoginResponse{AuthToken: authToken})
+		})
+		m.HandleFunc("/api/v1/listener/addTiredSong", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		require.Error(t, sut.AddTired(context.Background(), "foo"))
+		require.Equal(t, 2, logins)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("Not For Login", func(t *testing.T) {
+		logins := 0
+
+		m := http.NewServeMux()
+		m.HandleFunc("/api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+			logins++
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		sut, server, _ := setupClientTest(t, m, "")
+		defer server.Close()
+
+		require.Error(t, sut.Login(context.Background(), "un", "pw"))
+		require.Equal(t, 1, logins)
+	})
+}
+
+func TestClient_Cancellation(t *testing.T) {
+	authToken := uuid.Must(uuid.NewRandom()).String()
+	release := make(chan struct{})
+	m := http.NewServeMux()
+	expectLogin(t, m, authToken)
+	m.HandleFunc("/api/v1/station/getStations", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+
+	sut, server, _ := setupClientTest(t, m, authToken)
+	defer server.Close()
+	defer close(release)
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := sut.GetStations(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline exceeded, got %v", err)
+}
+
+func expectStationPages(t *testing.T, m *http.ServeMux, pageSize, total int) {
+	m.HandleFunc("/api/v1/station/getStations", func(w http.ResponseWriter, r *http.Request) {
+		v := StationRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		if v.StartIndex == 0 {
+			assert.Empty(t, v.SortedBy)
+		} else {
+			assert.Equal(t, StationSortOrderLastPlayed, v.SortedBy)
+		}
+
+		var stations []pandora.Station
+		for i := v.StartIndex; i < total && len(stations) < pageSize; i++ {
+			stations = append(stations, pandora.Station{
+				ID:   uuid.Must(uuid.NewRandom()).String(),
+				Name: fmt.Sprintf("Station %d", i),
+			})
+		}
+
+		testutil.MarshalResponse(t, http.StatusOK, w, &StationResponse{
+			TotalStations: total,
+			SortedBy:      StationSortOrderLastPlayed,
+			Index:         v.StartIndex,
+			Stations:      stations,
+		})
+	})
+}
+
+func TestClient_StreamStations(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		expectStationPages(t, m, 2, 5)
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+		var pages []int
+		require.NoError(t, sut.StreamStations(context.Background(), func(page []pandora.Station) error {
+			pages = append(pages, len(page))
+			return nil
+		}))
+
+		require.Equal(t, []int{2, 2, 1}, pages)
+	})
+
+	t.Run("Stops On Error", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		expectStationPages(t, m, 2, 5)
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+		calls := 0
+		err := sut.StreamStations(context.Background(), func(page []pandora.Station) error {
+			calls++
+			return fmt.Errorf("dummy")
+		})
+
+		require.EqualError(t, err, "dummy")
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestClient_CreateStation(t *testing.T) {
+	pandoraId := "AR:1234"
+
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		expected := pandora.Station{
+			ID:        uuid.Must(uuid.NewRandom()).String(),
+			PandoraId: "ST:1234",
+			Name:      "Dummy Radio",
+		}
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/createStation", func(w http.ResponseWriter, r *http.Request) {
+			v := CreateStationRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+
+			assert.Equal(t, pandoraId, v.PandoraID)
+
+			testutil.MarshalResponse(t, http.StatusOK, w, &expected)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		station, err := sut.CreateStation(context.Background(), pandoraId)
+		require.NoError(t, err)
+		assert.Equal(t, expected.ID, station.ID)
+		assert.Equal(t, expected.Name, station.Name)
+	})
+
+	t.Run("RequiresLogin", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
+		defer server.Close()
+
+		_, err := sut.CreateStation(context.Background(), pandoraId)
+		require.EqualError(t, err, "CreateStation: post: not logged in")
+	})
+}
+
+func TestClient_RenameStation(t *testing.T) {
+	stationId := uuid.Must(uuid.NewRandom()).String()
+
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/updateStation", func(w http.ResponseWriter, r *http.Request) {
+			v := UpdateStationRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+
+			assert.Equal(t, stationId, v.StationID)
+			assert.Equal(t, "New Name", v.Name)
+
+			w.WriteHeader(http.StatusOK)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		require.NoError(t, sut.RenameStation(context.Background(), stationId, "New Name"))
+	})
+
+	t.Run("RequiresLogin", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
+		defer server.Close()
+
+		err := sut.RenameStation(context.Background(), stationId, "New Name")
+		require.EqualError(t, err, "RenameStation: post: not logged in")
+	})
+}
+
+func TestClient_DeleteStation(t *testing.T) {
+	stationId := uuid.Must(uuid.NewRandom()).String()
+
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/removeStation", func(w http.ResponseWriter, r *http.Request) {
+			v := RemoveStationRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+
+			assert.Equal(t, stationId, v.StationID)
+
+			w.WriteHeader(http.StatusOK)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		require.NoError(t, sut.DeleteStation(context.Background(), stationId))
+	})
+
+	t.Run("StationDoesNotExist", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/removeStation", func(w http.ResponseWriter, r *http.Request) {
+			testutil.MarshalResponse(t, http.StatusBadRequest, w, &restError{
+				ErrorString: "STATION_DOES_NOT_EXIST",
+				Message:     "Station does not exist",
+				Code:        1006,
+			})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		err := sut.DeleteStation(context.Background(), stationId)
+		require.True(t, errors.Is(err, ErrStationDoesNotExist))
+	})
+}
+
+func TestClient_Search(t *testing.T) {
+	authToken := uuid.Must(uuid.NewRandom()).String()
+
+	m := http.NewServeMux()
+	expectLogin(t, m, authToken)
+	m.HandleFunc("/api/v3/sod/search", func(w http.ResponseWriter, r *http.Request) {
+		v := SearchRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, "dummy", v.Query)
+		assert.True(t, v.Annotate)
+
+		testutil.MarshalResponse(t, http.StatusOK, w, &SearchResponse{
+			Results: []string{"TR:1", "AR:1", "SF:1", "AR:2", "CO:1", "AR:3"},
+			Annotations: map[string]pandora.SearchResult{
+				"AR:1": {Type: pandora.SearchResultTypeArtist, Name: "Artist 1"},
+				"AR:2": {Type: pandora.SearchResultTypeArtist, Name: "Artist 2"},
+				"TR:1": {Type: pandora.SearchResultTypeTrack, Name: "Song", ArtistName: "Artist 1"},
+				"SF:1": {Type: pandora.SearchResultTypeGenre, Name: "Genre"},
+				"CO:1": {Type: "CO", Name: "Composer"},
+			},
+		})
+	})
+
+	sut, server, _ := setupClientTest(t, m, authToken)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	result, err := sut.Search(context.Background(), "dummy")
+	require.NoError(t, err)
+	assert.Equal(t, pandora.SearchResults{
+		Artists: []pandora.SearchResult{
+			{PandoraId: "AR:1", Type: pandora.SearchResultTypeArtist, Name: "Artist 1"},
+			{PandoraId: "AR:2", Type: pandora.SearchResultTypeArtist, Name: "Artist 2"},
+		},
+		Tracks: []pandora.SearchResult{
+			{PandoraId: "TR:1", Type: pandora.SearchResultTypeTrack, Name: "Song", ArtistName: "Artist 1"},
+		},
+		Genres: []pandora.SearchResult{
+			{PandoraId: "SF:1", Type: pandora.SearchResultTypeGenre, Name: "Genre"},
+		},
+	}, result)
+}
+
+func TestClient_StationSeeds(t *testing.T) {
+	authToken := uuid.Must(uuid.NewRandom()).String()
+	stationId := uuid.Must(uuid.NewRandom()).String()
+	seed := pandora.StationSeed{
+		SeedId:    "seed",
+		PandoraId: "AR:1",
+		Type:      pandora.SearchResultTypeArtist,
+		Name:      "Artist",
+	}
+
+	m := http.NewServeMux()
+	expectLogin(t, m, authToken)
+	m.HandleFunc("/api/v1/station/getStationDetails", func(w http.ResponseWriter, r *http.Request) {
+		v := StationDetailsRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, stationId, v.StationID)
+
+		testutil.MarshalResponse(t, http.StatusOK, w, &pandora.StationDetails{
+			Station:               pandora.Station{ID: stationId, Name: "Dummy Radio"},
+			InitialSeed:           seed,
+			Seeds:                 []pandora.StationSeed{seed},
+			PositiveFeedbackCount: 3,
+			NegativeFeedbackCount: 1,
+		})
+	})
+	m.HandleFunc("/api/v1/station/addSeed", func(w http.ResponseWriter, r *http.Request) {
+		v := AddSeedRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, stationId, v.StationID)
+		assert.Equal(t, seed.PandoraId, v.PandoraID)
+
+		testutil.MarshalResponse(t, http.StatusOK, w, &seed)
+	})
+	m.HandleFunc("/api/v1/station/removeSeed", func(w http.ResponseWriter, r *http.Request) {
+		v := RemoveSeedRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, stationId, v.StationID)
+		assert.Equal(t, seed.SeedId, v.SeedID)
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	sut, server, _ := setupClientTest(t, m, authToken)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	details, err := sut.GetStationDetails(context.Background(), stationId)
+	require.NoError(t, err)
+	assert.Equal(t, "Dummy Radio", details.Name)
+	assert.Equal(t, seed, details.InitialSeed)
+	assert.Equal(t, []pandora.StationSeed{seed}, details.Seeds)
+	assert.Equal(t, 3, details.PositiveFeedbackCount)
+	assert.Equal(t, 1, details.NegativeFeedbackCount)
+
+	added, err := sut.AddSeed(context.Background(), stationId, seed.PandoraId)
+	require.NoError(t, err)
+	assert.Equal(t, seed, added)
+
+	require.NoError(t, sut.RemoveSeed(context.Background(), stationId, seed.SeedId))
+}
+
 func TestClient_GetMoreTracks(t *testing.T) {
 	stationId := uuid.Must(uuid.NewRandom()).String()
 
@@ -175,18 +598,130 @@ func TestClient_GetMoreTracks(t *testing.T) {
 		sut, server, _ := setupClientTest(t, m, authToken)
 		defer server.Close()
 
-		require.NoError(t, sut.Login("un", "pw"))
-		tracks, err := sut.GetMoreTracks(stationId)
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		fragment, err := sut.GetMoreTracks(context.Background(), stationId, FragmentRequestReasonNormal)
+
+		require.NoError(t, err)
+		require.Len(t, fragment.Tracks, 4)
+		require.False(t, fragment.IsBingeSkipping)
+	})
+
+	t.Run("BingeSkipping", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/getFragment", func(w http.ResponseWriter, r *http.Request) {
+			testutil.MarshalResponse(t, http.StatusOK, w, &GetPlaylistFragmentResponse{
+				Tracks:          []pandora.Track{testutil.MakeTrack()},
+				IsBingeSkipping: true,
+			})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		fragment, err := sut.GetMoreTracks(context.Background(), stationId, FragmentRequestReasonSkip)
 
 		require.NoError(t, err)
-		require.Len(t, tracks, 4)
+		require.Len(t, fragment.Tracks, 1)
+		require.True(t, fragment.IsBingeSkipping)
+	})
+
+	t.Run("ContinuesStation", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		var requests []GetPlaylistFragmentRequest
+		var lastTracks []string
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/getFragment", func(w http.ResponseWriter, r *http.Request) {
+			v := GetPlaylistFragmentRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+			requests = append(requests, v)
+
+			tracks := []pandora.Track{testutil.MakeTrack(), testutil.MakeTrack()}
+			lastTracks = append(lastTracks, tracks[1].TrackKey.TrackID)
+
+			testutil.MarshalResponse(t, http.StatusOK, w, &GetPlaylistFragmentResponse{Tracks: tracks})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		for _, reason := range []FragmentRequestReason{
+			FragmentRequestReasonNormal,
+			FragmentRequestReasonNormal,
+			FragmentRequestReasonSkip,
+		} {
+			_, err := sut.GetMoreTracks(context.Background(), stationId, reason)
+			require.NoError(t, err)
+		}
+
+		require.Len(t, requests, 3)
+
+		require.True(t, requests[0].IsStationStart)
+		require.Nil(t, requests[0].StartingAtTrackId)
+		require.Equal(t, FragmentRequestReasonNormal, requests[0].FragmentRequestReason)
+
+		require.False(t, requests[1].IsStationStart)
+		require.NotNil(t, requests[1].StartingAtTrackId)
+		require.Equal(t, lastTracks[0], *requests[1].StartingAtTrackId)
+		require.Equal(t, FragmentRequestReasonNormal, requests[1].FragmentRequestReason)
+
+		require.False(t, requests[2].IsStationStart)
+		require.NotNil(t, requests[2].StartingAtTrackId)
+		require.Equal(t, lastTracks[1], *requests[2].StartingAtTrackId)
+		require.Equal(t, FragmentRequestReasonSkip, requests[2].FragmentRequestReason)
+	})
+
+	t.Run("StartsEachStation", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		starts := map[string]bool{}
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/getFragment", func(w http.ResponseWriter, r *http.Request) {
+			v := GetPlaylistFragmentRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+			starts[v.StationID] = v.IsStationStart
+
+			testutil.MarshalResponse(t, http.StatusOK, w, &GetPlaylistFragmentResponse{
+				Tracks: []pandora.Track{testutil.MakeTrack()},
+			})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.GetMoreTracks(context.Background(), stationId, FragmentRequestReasonNormal)
+		require.NoError(t, err)
+		_, err = sut.GetMoreTracks(context.Background(), "other", FragmentRequestReasonNormal)
+		require.NoError(t, err)
+
+		require.True(t, starts[stationId])
+		require.True(t, starts["other"])
+
+		_, err = sut.GetMoreTracks(context.Background(), stationId, FragmentRequestReasonNormal)
+		require.NoError(t, err)
+		require.False(t, starts[stationId])
+
+		sut.RestartStation(stationId)
+		_, err = sut.GetMoreTracks(context.Background(), stationId, FragmentRequestReasonNormal)
+		require.NoError(t, err)
+		require.True(t, starts[stationId], "Restarted stations should start over")
 	})
 
 	t.Run("RequiresLogin", func(t *testing.T) {
 		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
 		defer server.Close()
 
-		_, err := sut.GetMoreTracks(stationId)
+		_, err := sut.GetMoreTracks(context.Background(), stationId, FragmentRequestReasonNormal)
 		require.EqualError(t, err, "GetMoreTracks: post: not logged in")
 	})
 }
@@ -206,25 +741,80 @@ func TestClient_AddFeedback(t *testing.T) {
 			assert.Equal(t, trackToken, v.TrackToken)
 			assert.True(t, v.IsPositive)
 
-			testutil.MarshalResponse(t, http.StatusOK, w, &AddFeedbackResponse{})
+			testutil.MarshalResponse(t, http.StatusOK, w, &AddFeedbackResponse{
+				ID:         "feedback",
+				StationId:  "station",
+				IsPositive: true,
+			})
 		})
 
 		sut, server, _ := setupClientTest(t, m, authToken)
 		defer server.Close()
 
-		require.NoError(t, sut.Login("un", "pw"))
-		require.NoError(t, sut.AddFeedback(trackToken, true))
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		feedback, err := sut.AddFeedback(context.Background(), trackToken, true)
+		require.NoError(t, err)
+		assert.Equal(t, pandora.Feedback{ID: "feedback", StationId: "station", IsPositive: true}, feedback)
 	})
 
 	t.Run("RequiresLogin", func(t *testing.T) {
 		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
 		defer server.Close()
 
-		err := sut.AddFeedback(trackToken, true)
+		_, err := sut.AddFeedback(context.Background(), trackToken, true)
 		require.EqualError(t, err, "AddFeedback: post: not logged in")
 	})
 }
 
+func TestClient_GetStationFeedback(t *testing.T) {
+	authToken := uuid.Must(uuid.NewRandom()).String()
+	stationId := uuid.Must(uuid.NewRandom()).String()
+	total := feedbackPageSize + 5
+
+	m := http.NewServeMux()
+	expectLogin(t, m, authToken)
+	m.HandleFunc("/api/v1/station/getStationFeedback", func(w http.ResponseWriter, r *http.Request) {
+		v := StationFeedbackRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, stationId, v.StationID)
+		assert.Equal(t, feedbackPageSize, v.PageSize)
+
+		resp := StationFeedbackResponse{Total: total}
+		for i := v.StartIndex; i < total && i < v.StartIndex+v.PageSize; i++ {
+			resp.Feedback = append(resp.Feedback, pandora.Feedback{ID: fmt.Sprintf("%d", i)})
+		}
+
+		testutil.MarshalResponse(t, http.StatusOK, w, &resp)
+	})
+	m.HandleFunc("/api/v1/station/deleteFeedback", func(w http.ResponseWriter, r *http.Request) {
+		v := DeleteFeedbackRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, "42", v.FeedbackID)
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	sut, server, _ := setupClientTest(t, m, authToken)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	var pages [][]pandora.Feedback
+	require.NoError(t, sut.GetStationFeedback(context.Background(), stationId, func(page []pandora.Feedback) error {
+		pages = append(pages, page)
+		return nil
+	}))
+
+	require.Len(t, pages, 2)
+	assert.Len(t, pages[0], feedbackPageSize)
+	assert.Len(t, pages[1], 5)
+	assert.Equal(t, fmt.Sprintf("%d", total-1), pages[1][4].ID)
+
+	require.NoError(t, sut.DeleteFeedback(context.Background(), "42"))
+}
+
 func TestClient_AddTired(t *testing.T) {
 	trackToken := uuid.Must(uuid.NewRandom()).String()
 
@@ -245,15 +835,156 @@ func TestClient_AddTired(t *testing.T) {
 		sut, server, _ := setupClientTest(t, m, authToken)
 		defer server.Close()
 
-		require.NoError(t, sut.Login("un", "pw"))
-		require.NoError(t, sut.AddTired(trackToken))
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		require.NoError(t, sut.AddTired(context.Background(), trackToken))
 	})
 
 	t.Run("RequiresLogin", func(t *testing.T) {
 		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
 		defer server.Close()
 
-		err := sut.AddTired(trackToken)
+		err := sut.AddTired(context.Background(), trackToken)
 		require.EqualError(t, err, "AddTired: post: not logged in")
 	})
 }
+
+func TestClient_BookmarkSong(t *testing.T) {
+	trackToken := uuid.Must(uuid.NewRandom()).String()
+
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/bookmark/addSongBookmark", func(w http.ResponseWriter, r *http.Request) {
+			v := AddBookmarkRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+
+			assert.Equal(t, trackToken, v.TrackToken)
+
+			testutil.MarshalResponse(t, http.StatusOK, w, &pandora.Bookmark{
+				ID:         "bookmark",
+				PandoraId:  "TR:1",
+				SongTitle:  "Song",
+				ArtistName: "Artist",
+			})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		bookmark, err := sut.BookmarkSong(context.Background(), trackToken)
+		require.NoError(t, err)
+		require.Equal(t, pandora.Bookmark{
+			ID:         "bookmark",
+			Type:       pandora.BookmarkTypeSong,
+			PandoraId:  "TR:1",
+			SongTitle:  "Song",
+			ArtistName: "Artist",
+		}, bookmark)
+	})
+
+	t.Run("RequiresLogin", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
+		defer server.Close()
+
+		_, err := sut.BookmarkSong(context.Background(), trackToken)
+		require.EqualError(t, err, "BookmarkSong: post: not logged in")
+	})
+}
+
+func TestClient_BookmarkArtist(t *testing.T) {
+	trackToken := uuid.Must(uuid.NewRandom()).String()
+	authToken := uuid.Must(uuid.NewRandom()).String()
+
+	m := http.NewServeMux()
+	expectLogin(t, m, authToken)
+	m.HandleFunc("/api/v1/bookmark/addArtistBookmark", func(w http.ResponseWriter, r *http.Request) {
+		v := AddBookmarkRequest{}
+		testutil.UnmarshalRequest(t, r, &v)
+
+		assert.Equal(t, trackToken, v.TrackToken)
+
+		testutil.MarshalResponse(t, http.StatusOK, w, &pandora.Bookmark{
+			ID:         "bookmark",
+			PandoraId:  "AR:1",
+			ArtistName: "Artist",
+		})
+	})
+
+	sut, server, _ := setupClientTest(t, m, authToken)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+	bookmark, err := sut.BookmarkArtist(context.Background(), trackToken)
+	require.NoError(t, err)
+	require.Equal(t, pandora.BookmarkTypeArtist, bookmark.Type)
+	require.Equal(t, "AR:1", bookmark.PandoraId)
+}
+
+func TestClient_GetBookmarks(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/bookmark/getBookmarks", func(w http.ResponseWriter, r *http.Request) {
+			testutil.MarshalResponse(t, http.StatusOK, w, &GetBookmarksResponse{
+				Artists: []pandora.Bookmark{{ID: "a", PandoraId: "AR:1", ArtistName: "Artist"}},
+				Songs:   []pandora.Bookmark{{ID: "s", PandoraId: "TR:1", SongTitle: "Song", ArtistName: "Artist"}},
+			})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		bookmarks, err := sut.GetBookmarks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, pandora.Bookmarks{
+			Artists: []pandora.Bookmark{{ID: "a", Type: pandora.BookmarkTypeArtist, PandoraId: "AR:1", ArtistName: "Artist"}},
+			Songs:   []pandora.Bookmark{{ID: "s", Type: pandora.BookmarkTypeSong, PandoraId: "TR:1", SongTitle: "Song", ArtistName: "Artist"}},
+		}, bookmarks)
+	})
+
+	t.Run("RequiresLogin", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
+		defer server.Close()
+
+		_, err := sut.GetBookmarks(context.Background())
+		require.EqualError(t, err, "GetBookmarks: post: not logged in")
+	})
+}
+
+func TestClient_GetGenreCategories(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		expected := []pandora.GenreCategory{
+			{Name: "Rock", Stations: []pandora.GenreStation{{PandoraId: "G1", Name: "Classic Rock"}}},
+			{Name: "Jazz", Stations: []pandora.GenreStation{{PandoraId: "G2", Name: "Smooth Jazz"}}},
+		}
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/getGenreStations", func(w http.ResponseWriter, r *http.Request) {
+			testutil.MarshalResponse(t, http.StatusOK, w, &GenreStationsResponse{Categories: expected})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		categories, err := sut.GetGenreCategories(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, categories)
+	})
+
+	t.Run("RequiresLogin", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), uuid.Must(uuid.NewRandom()).String())
+		defer server.Close()
+
+		_, err := sut.GetGenreCategories(context.Background())
+		require.EqualError(t, err, "GetGenreCategories: post: not logged in")
+	})
+}
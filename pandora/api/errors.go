@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for well-known pandora failures. Use errors.Is to check if
+// an error returned by the client matches one of these.
+var (
+	ErrMaintenanceMode       = errors.New("pandora is down for maintenance")
+	ErrLicensingRestrictions = errors.New("pandora is not available in this country")
+	ErrReadOnlyMode          = errors.New("pandora is in read-only mode")
+	ErrInvalidAuth           = errors.New("invalid or expired auth token")
+	ErrInvalidCredentials    = errors.New("invalid username or password")
+	ErrListenerNotAuthorized = errors.New("listener not authorized")
+	ErrUserNotAuthorized     = errors.New("user not authorized")
+	ErrMaxStationsReached    = errors.New("station limit reached")
+	ErrStationDoesNotExist   = errors.New("station does not exist")
+	ErrCallNotAllowed        = errors.New("call not allowed")
+	ErrPlaylistExceeded      = errors.New("playlist limit exceeded")
+	ErrRateLimited           = errors.New("rate limited")
+)
+
+// Pandora error codes, shared between the REST and legacy APIs. See
+// https://6xq.net/pandora-apidoc/json/errorcodes/
+var errorCodes = map[int]error{
+	1:    ErrMaintenanceMode,
+	12:   ErrLicensingRestrictions,
+	1000: ErrReadOnlyMode,
+	1001: ErrInvalidAuth,
+	1002: ErrInvalidCredentials,
+	1003: ErrListenerNotAuthorized,
+	1004: ErrUserNotAuthorized,
+	1005: ErrMaxStationsReached,
+	1006: ErrStationDoesNotExist,
+	1008: ErrCallNotAllowed,
+	1011: ErrInvalidCredentials,
+	1012: ErrInvalidCredentials,
+	1039: ErrPlaylistExceeded,
+}
+
+var statusCodes = map[int]error{
+	http.StatusUnauthorized:    ErrInvalidAuth,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
+// Error is returned when pandora rejects a request. REST errors carry the
+// HTTP status of the response along with the errorCode, errorString, and
+// message from the body if it could be decoded. Legacy errors carry the code
+// and message from the response envelope.
+type Error struct {
+	StatusCode int
+	Status     string
+
+	Code        int
+	ErrorString string
+	Message     string
+
+	// Body is the raw response body if it could not be decoded
+	Body string
+}
+
+type restError struct {
+	Code        int    `json:"errorCode"`
+	ErrorString string `json:"errorString"`
+	Message     string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.ErrorString == "" && e.Message == "" && e.Code == 0 {
+		return fmt.Sprintf("unexpected result %s:\n%s", e.Status, e.Body)
+	}
+
+	var details []string
+	if e.ErrorString != "" {
+		details = append(details, e.ErrorString)
+	}
+
+	if e.Message != "" {
+		details = append(details, e.Message)
+	}
+
+	return fmt.Sprintf("pandora error %d: %s", e.Code, strings.Join(details, ": "))
+}
+
+// Is reports if e corresponds to one of the sentinel errors in this package
+func (e *Error) Is(target error) bool {
+	if sentinel, ok := errorCodes[e.Code]; ok && sentinel == target {
+		return true
+	}
+
+	if sentinel, ok := statusCodes[e.StatusCode]; ok && sentinel == target {
+		return true
+	}
+
+	return false
+}
+
+func newErrorFromBody(status int, statusText string, body []byte) *Error {
+	result := &Error{
+		StatusCode: status,
+		Status:     statusText,
+	}
+
+	payload := restError{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		result.Body = string(body)
+		return result
+	}
+
+	result.Code = payload.Code
+	result.ErrorString = payload.ErrorString
+	result.Message = payload.Message
+
+	if result.Message == "" && result.ErrorString == "" && result.Code == 0 {
+		result.Body = string(body)
+	}
+
+	return result
+}
+
+// peekError decodes the error in r without consuming the body, leaving it
+// intact for further inspection
+func peekError(r *http.Response) *Error {
+	body, _ := ioutil.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return newErrorFromBody(r.StatusCode, r.Status, body)
+}
+
+func newLegacyError(r LegacyResponse) *Error {
+	return &Error{
+		Code:    r.Code,
+		Message: r.Message,
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Run("Raw Body", func(t *testing.T) {
+		sut := newErrorFromBody(http.StatusTeapot, "418 I'm a teapot", []byte("Foobar"))
+		require.EqualError(t, sut, "unexpected result 418 I'm a teapot:\nFoobar")
+	})
+
+	t.Run("REST", func(t *testing.T) {
+		sut := newErrorFromBody(http.StatusBadRequest, "400 Bad Request", []byte(
+			`{"errorCode":1006,"errorString":"STATION_DOES_NOT_EXIST","message":"Station does not exist"}`,
+		))
+
+		require.EqualError(t, sut, "pandora error 1006: STATION_DOES_NOT_EXIST: Station does not exist")
+		require.Equal(t, http.StatusBadRequest, sut.StatusCode)
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		sut := newLegacyError(LegacyResponse{Stat: "fail", Code: 1002, Message: "Invalid login"})
+		require.EqualError(t, sut, "pandora error 1002: Invalid login")
+	})
+}
+
+func TestError_Is(t *testing.T) {
+	for code, sentinel := range errorCodes {
+		t.Run(sentinel.Error(), func(t *testing.T) {
+			var err error = fmt.Errorf("wrapped: %w", &Error{Code: code})
+			require.True(t, errors.Is(err, sentinel))
+		})
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		require.True(t, errors.Is(&Error{StatusCode: http.StatusUnauthorized}, ErrInvalidAuth))
+	})
+
+	t.Run("Rate Limited", func(t *testing.T) {
+		require.True(t, errors.Is(&Error{StatusCode: http.StatusTooManyRequests}, ErrRateLimited))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		sut := &Error{StatusCode: http.StatusInternalServerError, Code: 9999}
+		for _, sentinel := range errorCodes {
+			require.False(t, errors.Is(sut, sentinel))
+		}
+	})
+}
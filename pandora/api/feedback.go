@@ -1,5 +1,7 @@
 package api
 
+import "github.com/nlowe/mousiki/pandora"
+
 type AddFeedbackRequest struct {
 	TrackToken string `json:"trackToken"`
 	IsPositive bool   `json:"isPositive"`
@@ -19,3 +21,18 @@ type AddTiredRequest struct {
 }
 
 type AddTiredResponse struct{}
+
+type StationFeedbackRequest struct {
+	StationID  string `json:"stationId"`
+	PageSize   int    `json:"pageSize"`
+	StartIndex int    `json:"startIndex"`
+}
+
+type StationFeedbackResponse struct {
+	Total    int                `json:"total"`
+	Feedback []pandora.Feedback `json:"feedback"`
+}
+
+type DeleteFeedbackRequest struct {
+	FeedbackID string `json:"feedbackId"`
+}
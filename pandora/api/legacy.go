@@ -2,8 +2,10 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -47,7 +49,9 @@ type LegacyRequest struct {
 }
 
 type LegacyResponse struct {
-	Stat string `json:"stat"`
+	Stat    string `json:"stat"`
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code,omitempty"`
 }
 
 type LegacyPartnerLoginRequest struct {
@@ -84,6 +88,7 @@ type LegacyUserLoginRequest struct {
 }
 
 type LegacyUserLoginResponseResult struct {
+	UserID        string `json:"userId"`
 	UserAuthToken string `json:"userAuthToken"`
 }
 
@@ -92,7 +97,21 @@ type LegacyUserLoginResponse struct {
 	Result LegacyUserLoginResponseResult `json:"result"`
 }
 
-func (c *client) legacyPost(method, authToken, partnerId string, encrypt bool, payload interface{}) (*http.Response, error) {
+type legacyResponseEnvelope struct {
+	LegacyResponse
+	Result json.RawMessage `json:"result"`
+}
+
+// legacyAuth identifies the partner and user a legacy request is made on
+// behalf of
+type legacyAuth struct {
+	authToken  string
+	partnerID  string
+	userID     string
+	syncOffset time.Duration
+}
+
+func (c *client) legacyPost(ctx context.Context, method string, auth legacyAuth, encrypt bool, payload interface{}) (*http.Response, error) {
 	buff, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("legacyPost: marshal payload: %w", err)
@@ -106,9 +125,9 @@ func (c *client) legacyPost(method, authToken, partnerId string, encrypt bool, p
 		legacyEncrypt(encrypted, buff)
 
 		encoded := hex.EncodeToString(encrypted)
-		req, err = http.NewRequest(http.MethodPost, legacyAPIEndpoint, strings.NewReader(encoded))
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.legacyURL, strings.NewReader(encoded))
 	} else {
-		req, err = http.NewRequest(http.MethodPost, legacyAPIEndpoint, bytes.NewReader(buff))
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.legacyURL, bytes.NewReader(buff))
 	}
 
 	if err != nil {
@@ -118,25 +137,29 @@ func (c *client) legacyPost(method, authToken, partnerId string, encrypt bool, p
 	q := req.URL.Query()
 	q.Set("method", method)
 
-	if authToken != "" {
-		q.Set("auth_token", authToken)
+	if auth.authToken != "" {
+		q.Set("auth_token", auth.authToken)
+	}
+
+	if auth.partnerID != "" {
+		q.Set("partner_id", auth.partnerID)
 	}
 
-	if partnerId != "" {
-		q.Set("partner_id", partnerId)
+	if auth.userID != "" {
+		q.Set("user_id", auth.userID)
 	}
 
 	req.URL.RawQuery = q.Encode()
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Content-type", "text/plain")
 
 	return c.api.Do(req)
 }
 
-func (c *client) legacyPartnerLogin() (LegacyPartnerLoginResponseResult, error) {
+func (c *client) legacyPartnerLogin(ctx context.Context) (LegacyPartnerLoginResponseResult, error) {
 	c.log.WithFields(logrus.Fields{}).Trace("Attempting Partner Login")
 	// Perform Partner Login
-	resp, err := c.legacyPost("auth.partnerLogin", "", "", false, LegacyPartnerLoginRequest{
+	resp, err := c.legacyPost(ctx, "auth.partnerLogin", legacyAuth{}, false, LegacyPartnerLoginRequest{
 		Username:    legacyPartnerUsername,
 		Password:    legacyPartnerPassword,
 		DeviceModel: legacyPartnerDeviceID,
@@ -163,7 +186,7 @@ func (c *client) legacyPartnerLogin() (LegacyPartnerLoginResponseResult, error)
 	}
 
 	if payload.Stat != "ok" {
-		return LegacyPartnerLoginResponseResult{}, fmt.Errorf("partner login: request failed: %s", string(raw))
+		return LegacyPartnerLoginResponseResult{}, fmt.Errorf("partner login: %w", newLegacyError(payload.LegacyResponse))
 	}
 
 	c.log.WithFields(logrus.Fields{
@@ -175,41 +198,27 @@ func (c *client) legacyPartnerLogin() (LegacyPartnerLoginResponseResult, error)
 	return payload.Result, nil
 }
 
-func (c *client) LegacyLogin(username, password string) error {
+func (c *client) LegacyLogin(ctx context.Context, username, password string) error {
 	c.log.WithField("username", username).Debug("Attempting legacy login")
 
-	partner, err := c.legacyPartnerLogin()
+	partner, err := c.legacyPartnerLogin(ctx)
 	if err != nil {
 		return fmt.Errorf("login: %w", err)
 	}
 
-	rawEncryptedSyncTime, err := hex.DecodeString(partner.EncryptedSyncTime)
+	syncOffset, err := c.parseLegacySyncTime(partner.EncryptedSyncTime)
 	if err != nil {
-		return fmt.Errorf("login: decode sync time: %w", err)
-	}
-
-	rawDecryptedSyncTime := make([]byte, len(rawEncryptedSyncTime))
-	legacyDecrypt(rawDecryptedSyncTime, rawEncryptedSyncTime)
-
-	c.log.WithField("decryptedPartnerSyncTime", string(rawDecryptedSyncTime)).Trace("Decrypted Partner Sync Time")
-
-	partnerSyncTime, err := strconv.ParseInt(string(rawDecryptedSyncTime[4:14]), 10, 32)
-	if err != nil {
-		return fmt.Errorf("login: decode decrypted sync time: %w", err)
+		return fmt.Errorf("login: %w", err)
 	}
 
-	// TODO: We're calling these back-to-back. Do we even need sync time?
-	sync := time.Now().Add(time.Unix(partnerSyncTime, 0).Sub(time.Now())).UTC().Unix()
-	c.log.WithFields(logrus.Fields{
-		"partnerSyncTime": partnerSyncTime,
-		"syncTime":        sync,
-	}).Trace("Parsed partner sync time")
-
 	// TODO: Pandora doesn't like this call, always returns code 0
 	c.log.WithField("username", username).Trace("Performing User Login")
-	resp, err := c.legacyPost("auth.userLogin", partner.PartnerAuthToken, partner.PartnerID, true, LegacyUserLoginRequest{
+	resp, err := c.legacyPost(ctx, "auth.userLogin", legacyAuth{
+		authToken: partner.PartnerAuthToken,
+		partnerID: partner.PartnerID,
+	}, true, LegacyUserLoginRequest{
 		LegacyRequest: LegacyRequest{
-			SyncTime: sync,
+			SyncTime: c.legacySyncTime(syncOffset),
 		},
 		LoginType:        "user",
 		Username:         username,
@@ -237,14 +246,162 @@ func (c *client) LegacyLogin(username, password string) error {
 	}
 
 	if payload.Stat != "ok" {
-		return fmt.Errorf("login: request failed: %s", string(raw))
+		return fmt.Errorf("login: %w", newLegacyError(payload.LegacyResponse))
 	}
 
-	c.log.WithField("authToken", payload.Result.UserAuthToken).Trace("User Login Complete")
-	c.authToken = payload.Result.UserAuthToken
+	c.log.WithFields(logrus.Fields{
+		"authToken": payload.Result.UserAuthToken,
+		"userID":    payload.Result.UserID,
+	}).Trace("User Login Complete")
+	c.setLegacyAuth(partner.PartnerID, payload.Result.UserID, syncOffset)
+	c.setAuth(username, payload.Result.UserAuthToken, func(ctx context.Context) error {
+		return c.LegacyLogin(ctx, username, password)
+	})
+
 	return nil
 }
 
+func (c *client) setLegacyAuth(partnerID, userID string, syncOffset time.Duration) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	c.legacyPartnerID = partnerID
+	c.legacyUserID = userID
+	c.legacySyncOffset = syncOffset
+}
+
+// parseLegacySyncTime decrypts the sync time returned by the partner login and
+// calculates how far pandora's clock is from ours
+func (c *client) parseLegacySyncTime(encryptedSyncTime string) (time.Duration, error) {
+	rawEncryptedSyncTime, err := hex.DecodeString(encryptedSyncTime)
+	if err != nil {
+		return 0, fmt.Errorf("decode sync time: %w", err)
+	}
+
+	rawDecryptedSyncTime := make([]byte, len(rawEncryptedSyncTime))
+	legacyDecrypt(rawDecryptedSyncTime, rawEncryptedSyncTime)
+
+	c.log.WithField("decryptedPartnerSyncTime", string(rawDecryptedSyncTime)).Trace("Decrypted Partner Sync Time")
+
+	// The first four bytes are garbage, followed by the unix timestamp
+	if len(rawDecryptedSyncTime) < 14 {
+		return 0, fmt.Errorf("decode decrypted sync time: too short")
+	}
+
+	partnerSyncTime, err := strconv.ParseInt(string(rawDecryptedSyncTime[4:14]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("decode decrypted sync time: %w", err)
+	}
+
+	offset := time.Unix(partnerSyncTime, 0).Sub(c.now())
+	c.log.WithFields(logrus.Fields{
+		"partnerSyncTime": partnerSyncTime,
+		"offset":          offset,
+	}).Trace("Parsed partner sync time")
+
+	return offset, nil
+}
+
+// legacySyncTime is pandora's idea of the current time, which every
+// encrypted legacy request needs to include
+func (c *client) legacySyncTime(offset time.Duration) int64 {
+	return c.now().Add(offset).Unix()
+}
+
+func (c *client) currentLegacyAuth() legacyAuth {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	return legacyAuth{
+		authToken:  c.authToken,
+		partnerID:  c.legacyPartnerID,
+		userID:     c.legacyUserID,
+		syncOffset: c.legacySyncOffset,
+	}
+}
+
+// legacyCall performs an encrypted, authenticated legacy API call and decodes
+// the result into result. If pandora reports that the auth token has expired,
+// we log in again and retry the call once.
+func (c *client) legacyCall(ctx context.Context, policy retryPolicy, method string, payload, result interface{}) error {
+	auth := c.currentLegacyAuth()
+	err := c.doLegacyCall(ctx, policy, method, auth, payload, result)
+	if !errors.Is(err, ErrInvalidAuth) {
+		return err
+	}
+
+	c.log.WithField("method", method).Info("Auth token expired, logging in again")
+	if refreshErr := c.refreshAuth(ctx, auth.authToken); refreshErr != nil {
+		c.log.WithError(refreshErr).Warn("Failed to refresh auth token")
+		return err
+	}
+
+	return c.doLegacyCall(ctx, policy, method, c.currentLegacyAuth(), payload, result)
+}
+
+func (c *client) doLegacyCall(ctx context.Context, policy retryPolicy, method string, auth legacyAuth, payload, result interface{}) error {
+	if auth.authToken == "" {
+		return errNotLoggedIn
+	}
+
+	body, err := withLegacyUserAuthToken(payload, auth.authToken)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.withRetry(ctx, policy, c.log.WithField("method", method), func(ctx context.Context) (*http.Response, error) {
+		// Pandora rejects requests with a stale sync time, so calculate it
+		// for every attempt
+		body["syncTime"], _ = json.Marshal(c.legacySyncTime(auth.syncOffset))
+		return c.legacyPost(ctx, method, auth, true, body)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return err
+	}
+
+	envelope := legacyResponseEnvelope{}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	if envelope.Stat != "ok" {
+		return newLegacyError(envelope.LegacyResponse)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+
+	return nil
+}
+
+// withLegacyUserAuthToken adds the userAuthToken field every authenticated
+// legacy request needs to payload
+func withLegacyUserAuthToken(payload interface{}, authToken string) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	result := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	result["userAuthToken"], _ = json.Marshal(authToken)
+	return result, nil
+}
+
 func blowfishPad(b []byte) []byte {
 	return append(b, make([]byte, blowfishBlockSize-(len(b)%blowfishBlockSize))...)
 }
@@ -0,0 +1,861 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	legacyNarrativeIntro = "Based on what you've told us so far, we're playing this track because it features:"
+
+	legacyAudioQualityHigh = "highQuality"
+)
+
+// Formats that are not in the default audioUrlMap need to be requested as an
+// additional audio url
+var legacyAdditionalAudioFormats = map[pandora.AudioFormat]string{
+	pandora.AudioFormatMP3:        "HTTP_128_MP3",
+	pandora.AudioFormatPremiumMP3: "HTTP_192_MP3",
+}
+
+// LegacyDate is how the legacy API represents timestamps
+type LegacyDate struct {
+	Time int64 `json:"time"`
+}
+
+func (d LegacyDate) Unix() time.Time {
+	return time.Unix(0, d.Time*int64(time.Millisecond)).UTC()
+}
+
+type LegacyStationListRequest struct {
+	IncludeStationArtURL bool `json:"includeStationArtUrl"`
+}
+
+type LegacyStation struct {
+	StationID    string     `json:"stationId"`
+	StationToken string     `json:"stationToken"`
+	StationName  string     `json:"stationName"`
+	ArtURL       string     `json:"artUrl"`
+	DateCreated  LegacyDate `json:"dateCreated"`
+}
+
+type LegacyStationListResponse struct {
+	Stations []LegacyStation `json:"stations"`
+}
+
+type LegacyCreateStationRequest struct {
+	MusicToken string `json:"musicToken,omitempty"`
+	TrackToken string `json:"trackToken,omitempty"`
+	MusicType  string `json:"musicType,omitempty"`
+}
+
+type LegacySearchRequest struct {
+	SearchText           string `json:"searchText"`
+	IncludeGenreStations bool   `json:"includeGenreStations"`
+	IncludeNearMatches   bool   `json:"includeNearMatches"`
+}
+
+type LegacySearchResult struct {
+	MusicToken  string `json:"musicToken"`
+	ArtistName  string `json:"artistName"`
+	SongName    string `json:"songName"`
+	StationName string `json:"stationName"`
+	Score       int    `json:"score"`
+}
+
+type LegacySearchResponse struct {
+	Artists       []LegacySearchResult `json:"artists"`
+	Songs         []LegacySearchResult `json:"songs"`
+	GenreStations []LegacySearchResult `json:"genreStations"`
+}
+
+type LegacyGenreStation struct {
+	StationToken string `json:"stationToken"`
+	StationName  string `json:"stationName"`
+}
+
+type LegacyGenreCategory struct {
+	CategoryName string               `json:"categoryName"`
+	Stations     []LegacyGenreStation `json:"stations"`
+}
+
+type LegacyGenreStationsResponse struct {
+	Categories []LegacyGenreCategory `json:"categories"`
+}
+
+type LegacyRenameStationRequest struct {
+	StationToken string `json:"stationToken"`
+	StationName  string `json:"stationName"`
+}
+
+type LegacyStationRequest struct {
+	StationToken string `json:"stationToken"`
+}
+
+type LegacyGetStationRequest struct {
+	StationToken              string `json:"stationToken"`
+	IncludeExtendedAttributes bool   `json:"includeExtendedAttributes"`
+}
+
+type LegacySeed struct {
+	SeedID      string `json:"seedId"`
+	MusicToken  string `json:"musicToken"`
+	ArtistName  string `json:"artistName"`
+	SongName    string `json:"songName"`
+	StationName string `json:"stationName"`
+}
+
+type LegacyStationMusic struct {
+	Artists []LegacySeed `json:"artists"`
+	Songs   []LegacySeed `json:"songs"`
+	Genres  []LegacySeed `json:"genre"`
+}
+
+type LegacyStationFeedback struct {
+	ThumbsUp        []LegacyFeedback `json:"thumbsUp"`
+	ThumbsDown      []LegacyFeedback `json:"thumbsDown"`
+	TotalThumbsUp   int              `json:"totalThumbsUp"`
+	TotalThumbsDown int              `json:"totalThumbsDown"`
+}
+
+type LegacyStationDetails struct {
+	LegacyStation
+
+	Music    LegacyStationMusic    `json:"music"`
+	Feedback LegacyStationFeedback `json:"feedback"`
+}
+
+type LegacyAddMusicRequest struct {
+	StationToken string `json:"stationToken"`
+	MusicToken   string `json:"musicToken"`
+}
+
+type LegacyDeleteMusicRequest struct {
+	SeedID string `json:"seedId"`
+}
+
+type LegacyPlaylistRequest struct {
+	StationToken       string `json:"stationToken"`
+	AdditionalAudioURL string `json:"additionalAudioUrl,omitempty"`
+	IncludeTrackLength bool   `json:"includeTrackLength"`
+}
+
+type LegacyAudioURL struct {
+	Bitrate  string `json:"bitrate"`
+	Encoding string `json:"encoding"`
+	AudioURL string `json:"audioUrl"`
+	Protocol string `json:"protocol"`
+}
+
+type LegacyPlaylistItem struct {
+	TrackToken string `json:"trackToken"`
+	AdToken    string `json:"adToken"`
+	StationID  string `json:"stationId"`
+
+	ArtistName  string `json:"artistName"`
+	AlbumName   string `json:"albumName"`
+	SongName    string `json:"songName"`
+	AlbumArtURL string `json:"albumArtUrl"`
+
+	SongRating    int    `json:"songRating"`
+	TrackGain     string `json:"trackGain"`
+	TrackLength   int    `json:"trackLength"`
+	AllowFeedback bool   `json:"allowFeedback"`
+
+	AudioURLMap map[string]LegacyAudioURL `json:"audioUrlMap"`
+	// AdditionalAudioURL is a single url if one additional format was
+	// requested, or a list of urls if multiple formats were requested
+	AdditionalAudioURL json.RawMessage `json:"additionalAudioUrl"`
+}
+
+type LegacyPlaylistResponse struct {
+	Items []LegacyPlaylistItem `json:"items"`
+}
+
+type LegacyAddFeedbackRequest struct {
+	StationToken string `json:"stationToken"`
+	TrackToken   string `json:"trackToken"`
+	IsPositive   bool   `json:"isPositive"`
+}
+
+type LegacyFeedback struct {
+	FeedbackID string `json:"feedbackId"`
+	IsPositive bool   `json:"isPositive"`
+	MusicToken string `json:"musicToken"`
+	SongName   string `json:"songName"`
+	ArtistName string `json:"artistName"`
+}
+
+type LegacyDeleteFeedbackRequest struct {
+	FeedbackID string `json:"feedbackId"`
+}
+
+type LegacyTrackRequest struct {
+	TrackToken string `json:"trackToken"`
+}
+
+type LegacyBookmark struct {
+	BookmarkToken string `json:"bookmarkToken"`
+	MusicToken    string `json:"musicToken"`
+	ArtistName    string `json:"artistName"`
+	SongName      string `json:"songName"`
+	AlbumName     string `json:"albumName"`
+}
+
+type LegacyBookmarksResponse struct {
+	Artists []LegacyBookmark `json:"artists"`
+	Songs   []LegacyBookmark `json:"songs"`
+}
+
+type LegacyExplanation struct {
+	FocusTraitID   string `json:"focusTraitId"`
+	FocusTraitName string `json:"focusTraitName"`
+}
+
+type LegacyExplainTrackResponse struct {
+	Explanations []LegacyExplanation `json:"explanations"`
+}
+
+// legacyClient implements Client using the legacy JSON API defined in
+// https://6xq.net/pandora-apidoc/json/ instead of the REST API.
+//
+// The legacy API identifies stations by their station token and tracks by
+// their track token, so those are used as the station and music IDs.
+type legacyClient struct {
+	*client
+
+	// Feedback is added to a station, but the Client interface only gives us
+	// the track token. Remember which station each track came from.
+	trackStationsLock sync.Mutex
+	trackStations     map[string]string
+}
+
+// NewLegacyClient creates a pandora client that only uses the legacy JSON API,
+// customized by opts
+func NewLegacyClient(opts ...Option) *legacyClient {
+	c := NewClient(append([]Option{WithLogger(logrus.WithField("prefix", "legacyClient"))}, opts...)...)
+
+	return &legacyClient{
+		client:        c,
+		trackStations: map[string]string{},
+	}
+}
+
+func (c *legacyClient) Login(ctx context.Context, username, password string) error {
+	return c.LegacyLogin(ctx, username, password)
+}
+
+// ResumeSession restores a session returned by Session. The legacy API can't
+// exchange an auth token for a new one, so we perform a fresh partner login
+// and check that pandora still accepts the stored user auth token.
+func (c *legacyClient) ResumeSession(ctx context.Context, s Session) error {
+	c.log.WithField("username", s.Username).Debug("Attempting to resume session")
+
+	if s.AuthToken == "" || s.UserID == "" {
+		return errors.New("resume session: not a legacy session")
+	}
+
+	partner, err := c.legacyPartnerLogin(ctx)
+	if err != nil {
+		return fmt.Errorf("resume session: %w", err)
+	}
+
+	syncOffset, err := c.parseLegacySyncTime(partner.EncryptedSyncTime)
+	if err != nil {
+		return fmt.Errorf("resume session: %w", err)
+	}
+
+	c.setLegacyAuth(partner.PartnerID, s.UserID, syncOffset)
+	c.setAuth(s.Username, s.AuthToken, nil)
+
+	if err := c.doLegacyCall(ctx, retryIdempotent, "user.getStationListChecksum", c.currentLegacyAuth(), struct{}{}, nil); err != nil {
+		c.setLegacyAuth("", "", 0)
+		c.setAuth("", "", nil)
+		return fmt.Errorf("resume session: %w", err)
+	}
+
+	c.log.WithField("username", s.Username).Info("Resumed Session")
+	return nil
+}
+
+func (c *legacyClient) GetStations(ctx context.Context) ([]pandora.Station, error) {
+	var result []pandora.Station
+	err := c.StreamStations(ctx, func(page []pandora.Station) error {
+		result = append(result, page...)
+		return nil
+	})
+
+	return result, err
+}
+
+// StreamStations fetches the station list. The legacy API does not page
+// stations, so f is only ever called once.
+func (c *legacyClient) StreamStations(ctx context.Context, f func(page []pandora.Station) error) error {
+	c.log.Debug("Fetching Stations")
+
+	payload := LegacyStationListResponse{}
+	if err := c.legacyCall(ctx, retryIdempotent, "user.getStationList", LegacyStationListRequest{
+		IncludeStationArtURL: true,
+	}, &payload); err != nil {
+		return fmt.Errorf("GetStations: %w", err)
+	}
+
+	stations := make([]pandora.Station, 0, len(payload.Stations))
+	for _, s := range payload.Stations {
+		stations = append(stations, s.toStation())
+	}
+
+	return f(stations)
+}
+
+// CreateStation creates a new station. The legacy API creates stations from
+// music tokens returned by Search, or from the track token of a track that
+// was returned by GetMoreTracks.
+func (c *legacyClient) CreateStation(ctx context.Context, musicToken string) (pandora.Station, error) {
+	c.log.WithField("musicToken", musicToken).Debug("Creating Station")
+
+	req := LegacyCreateStationRequest{MusicToken: musicToken}
+
+	c.trackStationsLock.Lock()
+	if _, ok := c.trackStations[musicToken]; ok {
+		req = LegacyCreateStationRequest{TrackToken: musicToken, MusicType: "song"}
+	}
+	c.trackStationsLock.Unlock()
+
+	payload := LegacyStation{}
+	if err := c.legacyCall(ctx, retryUnsent, "station.createStation", req, &payload); err != nil {
+		return pandora.Station{}, fmt.Errorf("CreateStation: %w", err)
+	}
+
+	return payload.toStation(), nil
+}
+
+func (c *legacyClient) RenameStation(ctx context.Context, stationToken, name string) error {
+	c.log.WithFields(logrus.Fields{
+		"station": stationToken,
+		"name":    name,
+	}).Debug("Renaming Station")
+
+	if err := c.legacyCall(ctx, retryIdempotent, "station.renameStation", LegacyRenameStationRequest{
+		StationToken: stationToken,
+		StationName:  name,
+	}, nil); err != nil {
+		return fmt.Errorf("RenameStation: %w", err)
+	}
+
+	return nil
+}
+
+func (c *legacyClient) DeleteStation(ctx context.Context, stationToken string) error {
+	c.log.WithField("station", stationToken).Debug("Deleting Station")
+
+	if err := c.legacyCall(ctx, retryUnsent, "station.deleteStation", LegacyStationRequest{
+		StationToken: stationToken,
+	}, nil); err != nil {
+		return fmt.Errorf("DeleteStation: %w", err)
+	}
+
+	return nil
+}
+
+// GetStationDetails fetches the seeds and feedback counts for a station. The
+// legacy API doesn't say which seed a station was created from, so the first
+// seed is reported as the initial seed.
+func (c *legacyClient) GetStationDetails(ctx context.Context, stationToken string) (pandora.StationDetails, error) {
+	c.log.WithField("station", stationToken).Debug("Fetching Station Details")
+
+	payload := LegacyStationDetails{}
+	if err := c.legacyCall(ctx, retryIdempotent, "station.getStation", LegacyGetStationRequest{
+		StationToken:              stationToken,
+		IncludeExtendedAttributes: true,
+	}, &payload); err != nil {
+		return pandora.StationDetails{}, fmt.Errorf("GetStationDetails: %w", err)
+	}
+
+	result := pandora.StationDetails{
+		Station:               payload.toStation(),
+		PositiveFeedbackCount: payload.Feedback.TotalThumbsUp,
+		NegativeFeedbackCount: payload.Feedback.TotalThumbsDown,
+	}
+
+	for _, seed := range payload.Music.Artists {
+		result.Seeds = append(result.Seeds, seed.toSeed(pandora.SearchResultTypeArtist))
+	}
+
+	for _, seed := range payload.Music.Songs {
+		result.Seeds = append(result.Seeds, seed.toSeed(pandora.SearchResultTypeTrack))
+	}
+
+	for _, seed := range payload.Music.Genres {
+		result.Seeds = append(result.Seeds, seed.toSeed(pandora.SearchResultTypeGenre))
+	}
+
+	if len(result.Seeds) > 0 {
+		result.InitialSeed = result.Seeds[0]
+	}
+
+	return result, nil
+}
+
+// AddSeed adds the music token returned by Search as a seed for the station
+func (c *legacyClient) AddSeed(ctx context.Context, stationToken, musicToken string) (pandora.StationSeed, error) {
+	c.log.WithFields(logrus.Fields{
+		"station":    stationToken,
+		"musicToken": musicToken,
+	}).Debug("Adding Seed")
+
+	payload := LegacySeed{}
+	if err := c.legacyCall(ctx, retryUnsent, "station.addMusic", LegacyAddMusicRequest{
+		StationToken: stationToken,
+		MusicToken:   musicToken,
+	}, &payload); err != nil {
+		return pandora.StationSeed{}, fmt.Errorf("AddSeed: %w", err)
+	}
+
+	seedType := pandora.SearchResultTypeArtist
+	if payload.SongName != "" {
+		seedType = pandora.SearchResultTypeTrack
+	}
+
+	return payload.toSeed(seedType), nil
+}
+
+// RemoveSeed removes a seed from a station. Seed IDs are unique across
+// stations in the legacy API, so the station token is not used.
+func (c *legacyClient) RemoveSeed(ctx context.Context, _, seedId string) error {
+	c.log.WithField("seed", seedId).Debug("Removing Seed")
+
+	if err := c.legacyCall(ctx, retryIdempotent, "station.deleteMusic", LegacyDeleteMusicRequest{
+		SeedID: seedId,
+	}, nil); err != nil {
+		return fmt.Errorf("RemoveSeed: %w", err)
+	}
+
+	return nil
+}
+
+// GetGenreCategories fetches pandora's catalog of genre stations. The
+// PandoraId of each station is the token to pass to CreateStation.
+func (c *legacyClient) GetGenreCategories(ctx context.Context) ([]pandora.GenreCategory, error) {
+	c.log.Debug("Fetching Genre Stations")
+
+	payload := LegacyGenreStationsResponse{}
+	if err := c.legacyCall(ctx, retryIdempotent, "station.getGenreStations", struct{}{}, &payload); err != nil {
+		return nil, fmt.Errorf("GetGenreCategories: %w", err)
+	}
+
+	result := make([]pandora.GenreCategory, 0, len(payload.Categories))
+	for _, category := range payload.Categories {
+		stations := make([]pandora.GenreStation, 0, len(category.Stations))
+		for _, station := range category.Stations {
+			stations = append(stations, pandora.GenreStation{
+				PandoraId: station.StationToken,
+				Name:      station.StationName,
+			})
+		}
+
+		result = append(result, pandora.GenreCategory{
+			Name:     category.CategoryName,
+			Stations: stations,
+		})
+	}
+
+	return result, nil
+}
+
+// Search finds artists, tracks, and genres matching query. The PandoraId of
+// each result is the music token to pass to CreateStation.
+func (c *legacyClient) Search(ctx context.Context, query string) (pandora.SearchResults, error) {
+	c.log.WithField("query", query).Debug("Searching")
+
+	payload := LegacySearchResponse{}
+	if err := c.legacyCall(ctx, retryIdempotent, "music.search", LegacySearchRequest{
+		SearchText:           query,
+		IncludeGenreStations: true,
+		IncludeNearMatches:   true,
+	}, &payload); err != nil {
+		return pandora.SearchResults{}, fmt.Errorf("Search: %w", err)
+	}
+
+	result := pandora.SearchResults{}
+	for _, a := range payload.Artists {
+		result.Artists = append(result.Artists, pandora.SearchResult{
+			PandoraId: a.MusicToken,
+			Type:      pandora.SearchResultTypeArtist,
+			Name:      a.ArtistName,
+		})
+	}
+
+	for _, s := range payload.Songs {
+		result.Tracks = append(result.Tracks, pandora.SearchResult{
+			PandoraId:  s.MusicToken,
+			Type:       pandora.SearchResultTypeTrack,
+			Name:       s.SongName,
+			ArtistName: s.ArtistName,
+		})
+	}
+
+	for _, g := range payload.GenreStations {
+		result.Genres = append(result.Genres, pandora.SearchResult{
+			PandoraId: g.MusicToken,
+			Type:      pandora.SearchResultTypeGenre,
+			Name:      g.StationName,
+		})
+	}
+
+	return result, nil
+}
+
+// GetMoreTracks fetches more tracks for a station. The legacy API keeps track
+// of where each station left off on its own, so reason is ignored.
+func (c *legacyClient) GetMoreTracks(ctx context.Context, stationId string, _ FragmentRequestReason) (pandora.Fragment, error) {
+	f := pandora.AudioFormat(viper.GetString("audio-format"))
+	c.log.WithFields(logrus.Fields{
+		"station":     stationId,
+		"audioFormat": f,
+	}).Debug("Fetching more tracks")
+
+	additionalFormat := legacyAdditionalAudioFormats[f]
+
+	payload := LegacyPlaylistResponse{}
+	if err := c.legacyCall(ctx, retryIdempotent, "station.getPlaylist", LegacyPlaylistRequest{
+		StationToken:       stationId,
+		AdditionalAudioURL: additionalFormat,
+		IncludeTrackLength: true,
+	}, &payload); err != nil {
+		return pandora.Fragment{}, fmt.Errorf("GetMoreTracks: %w", err)
+	}
+
+	var result []pandora.Track
+	for _, item := range payload.Items {
+		if item.TrackToken == "" {
+			// Skip ads, they don't have a track token
+			continue
+		}
+
+		t, err := item.toTrack(stationId, f)
+		if err != nil {
+			c.log.WithError(err).WithField("track", item.TrackToken).Warn("Skipping unplayable track")
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	c.trackStationsLock.Lock()
+	defer c.trackStationsLock.Unlock()
+	for _, t := range result {
+		c.trackStations[t.TrackToken] = stationId
+	}
+
+	return pandora.Fragment{Tracks: result}, nil
+}
+
+// RestartStation is a no-op, the legacy API decides where stations start on its
+// own
+func (c *legacyClient) RestartStation(_ string) {}
+
+func (c *legacyClient) AddFeedback(ctx context.Context, trackToken string, isPositive bool) (pandora.Feedback, error) {
+	c.log.WithFields(logrus.Fields{
+		"track":      trackToken,
+		"isPositive": isPositive,
+	}).Debug("Adding Feedback")
+
+	c.trackStationsLock.Lock()
+	stationToken, ok := c.trackStations[trackToken]
+	c.trackStationsLock.Unlock()
+
+	if !ok {
+		return pandora.Feedback{}, fmt.Errorf("AddFeedback: unknown track %s", trackToken)
+	}
+
+	payload := LegacyFeedback{}
+	if err := c.legacyCall(ctx, retryUnsent, "station.addFeedback", LegacyAddFeedbackRequest{
+		StationToken: stationToken,
+		TrackToken:   trackToken,
+		IsPositive:   isPositive,
+	}, &payload); err != nil {
+		return pandora.Feedback{}, fmt.Errorf("AddFeedback: %w", err)
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"trackToken": trackToken,
+		"feedbackId": payload.FeedbackID,
+		"isPositive": payload.IsPositive,
+	}).Debug("Feedback Added")
+
+	return payload.toFeedback(stationToken), nil
+}
+
+// GetStationFeedback fetches the thumbs up and thumbs down given to tracks on
+// a station. The legacy API does not page feedback, so f is only ever called
+// once.
+func (c *legacyClient) GetStationFeedback(ctx context.Context, stationToken string, f func(page []pandora.Feedback) error) error {
+	c.log.WithField("station", stationToken).Debug("Fetching Station Feedback")
+
+	payload := LegacyStationDetails{}
+	if err := c.legacyCall(ctx, retryIdempotent, "station.getStation", LegacyGetStationRequest{
+		StationToken:              stationToken,
+		IncludeExtendedAttributes: true,
+	}, &payload); err != nil {
+		return fmt.Errorf("GetStationFeedback: %w", err)
+	}
+
+	result := make([]pandora.Feedback, 0, len(payload.Feedback.ThumbsUp)+len(payload.Feedback.ThumbsDown))
+	for _, feedback := range append(payload.Feedback.ThumbsUp, payload.Feedback.ThumbsDown...) {
+		result = append(result, feedback.toFeedback(stationToken))
+	}
+
+	return f(result)
+}
+
+func (c *legacyClient) DeleteFeedback(ctx context.Context, feedbackId string) error {
+	c.log.WithField("feedbackId", feedbackId).Debug("Deleting Feedback")
+
+	if err := c.legacyCall(ctx, retryIdempotent, "station.deleteFeedback", LegacyDeleteFeedbackRequest{
+		FeedbackID: feedbackId,
+	}, nil); err != nil {
+		return fmt.Errorf("DeleteFeedback: %w", err)
+	}
+
+	return nil
+}
+
+func (c *legacyClient) AddTired(ctx context.Context, trackToken string) error {
+	c.log.WithFields(logrus.Fields{
+		"track": trackToken,
+	}).Debug("Adding Tired Song")
+
+	if err := c.legacyCall(ctx, retryUnsent, "user.sleepSong", LegacyTrackRequest{
+		TrackToken: trackToken,
+	}, nil); err != nil {
+		return fmt.Errorf("AddTired: %w", err)
+	}
+
+	return nil
+}
+
+func (c *legacyClient) BookmarkSong(ctx context.Context, trackToken string) (pandora.Bookmark, error) {
+	result, err := c.addBookmark(ctx, "bookmark.addSongBookmark", trackToken, pandora.BookmarkTypeSong)
+	if err != nil {
+		return pandora.Bookmark{}, fmt.Errorf("BookmarkSong: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *legacyClient) BookmarkArtist(ctx context.Context, trackToken string) (pandora.Bookmark, error) {
+	result, err := c.addBookmark(ctx, "bookmark.addArtistBookmark", trackToken, pandora.BookmarkTypeArtist)
+	if err != nil {
+		return pandora.Bookmark{}, fmt.Errorf("BookmarkArtist: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *legacyClient) addBookmark(ctx context.Context, method, trackToken string, kind pandora.BookmarkType) (pandora.Bookmark, error) {
+	c.log.WithFields(logrus.Fields{
+		"track": trackToken,
+		"type":  kind,
+	}).Debug("Adding Bookmark")
+
+	payload := LegacyBookmark{}
+	if err := c.legacyCall(ctx, retryIdempotent, method, LegacyTrackRequest{
+		TrackToken: trackToken,
+	}, &payload); err != nil {
+		return pandora.Bookmark{}, err
+	}
+
+	return payload.toBookmark(kind), nil
+}
+
+// GetBookmarks fetches the bookmarked artists and songs. The PandoraId of each
+// bookmark is the music token to pass to CreateStation.
+func (c *legacyClient) GetBookmarks(ctx context.Context) (pandora.Bookmarks, error) {
+	c.log.Debug("Fetching Bookmarks")
+
+	payload := LegacyBookmarksResponse{}
+	if err := c.legacyCall(ctx, retryIdempotent, "user.getBookmarks", struct{}{}, &payload); err != nil {
+		return pandora.Bookmarks{}, fmt.Errorf("GetBookmarks: %w", err)
+	}
+
+	result := pandora.Bookmarks{
+		Artists: make([]pandora.Bookmark, 0, len(payload.Artists)),
+		Songs:   make([]pandora.Bookmark, 0, len(payload.Songs)),
+	}
+
+	for _, b := range payload.Artists {
+		result.Artists = append(result.Artists, b.toBookmark(pandora.BookmarkTypeArtist))
+	}
+
+	for _, b := range payload.Songs {
+		result.Songs = append(result.Songs, b.toBookmark(pandora.BookmarkTypeSong))
+	}
+
+	return result, nil
+}
+
+// GetNarrative explains why a track was picked. The legacy API only returns
+// the focus traits for the track, so the paragraph is built from those.
+func (c *legacyClient) GetNarrative(ctx context.Context, stationId, musicId string) (pandora.Narrative, error) {
+	c.log.WithFields(logrus.Fields{
+		"station": stationId,
+		"track":   musicId,
+	}).Debug("Getting Narrative")
+
+	payload := LegacyExplainTrackResponse{}
+	if err := c.legacyCall(ctx, retryIdempotent, "track.explainTrack", LegacyTrackRequest{
+		TrackToken: musicId,
+	}, &payload); err != nil {
+		return pandora.Narrative{}, fmt.Errorf("GetNarrative: %w", err)
+	}
+
+	result := pandora.Narrative{Intro: legacyNarrativeIntro}
+	for _, e := range payload.Explanations {
+		result.FocusTraits = append(result.FocusTraits, e.FocusTraitName)
+	}
+
+	result.Paragraph = fmt.Sprintf("%s %s.", strings.TrimSuffix(result.Intro, ":"), joinTraits(result.FocusTraits))
+	return result, nil
+}
+
+func (s LegacyStation) toStation() pandora.Station {
+	station := pandora.Station{
+		ID:        s.StationToken,
+		PandoraId: s.StationID,
+		Name:      s.StationName,
+		CreatedAt: s.DateCreated.Unix(),
+	}
+
+	if s.ArtURL != "" {
+		station.Art = []pandora.Art{{URL: s.ArtURL}}
+	}
+
+	return station
+}
+
+func (f LegacyFeedback) toFeedback(stationToken string) pandora.Feedback {
+	return pandora.Feedback{
+		ID:         f.FeedbackID,
+		StationId:  stationToken,
+		PandoraId:  f.MusicToken,
+		IsPositive: f.IsPositive,
+		SongTitle:  f.SongName,
+		ArtistName: f.ArtistName,
+	}
+}
+
+func (s LegacySeed) toSeed(seedType pandora.SearchResultType) pandora.StationSeed {
+	seed := pandora.StationSeed{
+		SeedId:     s.SeedID,
+		PandoraId:  s.MusicToken,
+		Type:       seedType,
+		Name:       s.ArtistName,
+		ArtistName: s.ArtistName,
+	}
+
+	switch seedType {
+	case pandora.SearchResultTypeTrack:
+		seed.Name = s.SongName
+	case pandora.SearchResultTypeGenre:
+		seed.Name = s.StationName
+		seed.ArtistName = ""
+	}
+
+	return seed
+}
+
+// toTrack converts a playlist item to a track, using the additional audio url
+// if format had to be requested as one
+func (i LegacyPlaylistItem) toTrack(stationToken string, format pandora.AudioFormat) (pandora.Track, error) {
+	t := pandora.Track{
+		MusicId:    i.TrackToken,
+		PandoraId:  i.TrackToken,
+		StationId:  stationToken,
+		TrackToken: i.TrackToken,
+		TrackType:  pandora.TrackTypeTrack,
+
+		TrackLengthSeconds: i.TrackLength,
+		Rating:             pandora.TrackRating(i.SongRating),
+
+		ArtistName: i.ArtistName,
+		AlbumTitle: i.AlbumName,
+		SongTitle:  i.SongName,
+
+		AllowFeedback:     i.AllowFeedback,
+		AllowTiredOfTrack: true,
+		// Stations can be created from any track with its track token
+		AllowStartStationFromTrack: true,
+		// The legacy API doesn't tell us about skip limits, so keep track
+		// of them ourselves like pandora does
+		AllowSkip:                  true,
+		AllowSkipTrackWithoutLimit: false,
+	}
+
+	t.TrackKey.TrackID = i.TrackToken
+	t.TrackKey.TrackType = pandora.TrackTypeTrack
+
+	if i.TrackGain != "" {
+		gain, err := strconv.ParseFloat(i.TrackGain, 64)
+		if err != nil {
+			return pandora.Track{}, fmt.Errorf("parse track gain: %w", err)
+		}
+
+		t.FileGain = gain
+	}
+
+	if i.AlbumArtURL != "" {
+		t.AlbumArt = []pandora.Art{{URL: i.AlbumArtURL}}
+	}
+
+	if _, ok := legacyAdditionalAudioFormats[format]; ok {
+		var url string
+		if err := json.Unmarshal(i.AdditionalAudioURL, &url); err != nil {
+			return pandora.Track{}, fmt.Errorf("decode additional audio url: %w", err)
+		}
+
+		t.AudioUrl = url
+		t.AudioEncoding = format
+	} else if high, ok := i.AudioURLMap[legacyAudioQualityHigh]; ok {
+		t.AudioUrl = high.AudioURL
+		t.AudioEncoding = pandora.AudioFormat(high.Encoding)
+	}
+
+	if t.AudioUrl == "" {
+		return pandora.Track{}, errors.New("no audio url")
+	}
+
+	return t, nil
+}
+
+func joinTraits(traits []string) string {
+	switch len(traits) {
+	case 0:
+		return ""
+	case 1:
+		return traits[0]
+	default:
+		return fmt.Sprintf("%s and %s", strings.Join(traits[:len(traits)-1], ", "), traits[len(traits)-1])
+	}
+}
+
+func (b LegacyBookmark) toBookmark(kind pandora.BookmarkType) pandora.Bookmark {
+	return pandora.Bookmark{
+		ID:         b.BookmarkToken,
+		Type:       kind,
+		PandoraId:  b.MusicToken,
+		SongTitle:  b.SongName,
+		ArtistName: b.ArtistName,
+		AlbumTitle: b.AlbumName,
+	}
+}
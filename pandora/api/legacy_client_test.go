@@ -0,0 +1,620 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLegacyPartnerID = "42"
+	testLegacyUserID    = "1337"
+	testLegacyAuthToken = "userAuthToken"
+)
+
+type legacyHandler func(t *testing.T, r *http.Request, body map[string]interface{}) interface{}
+
+func legacyEncryptedSyncTime(ts int64) string {
+	raw := blowfishPad([]byte(fmt.Sprintf("xxxx%010d", ts)))
+	encrypted := make([]byte, len(raw))
+	for bs := 0; bs < len(raw); bs += blowfishBlockSize {
+		legacyDecryptCipher.Encrypt(encrypted[bs:bs+blowfishBlockSize], raw[bs:bs+blowfishBlockSize])
+	}
+
+	return hex.EncodeToString(encrypted)
+}
+
+func decodeLegacyRequest(t *testing.T, r *http.Request) map[string]interface{} {
+	raw, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+
+	if r.URL.Query().Get("method") != "auth.partnerLogin" {
+		encrypted, err := hex.DecodeString(string(raw))
+		require.NoError(t, err)
+
+		raw = make([]byte, len(encrypted))
+		for bs := 0; bs < len(encrypted); bs += blowfishBlockSize {
+			legacyEncryptCipher.Decrypt(raw[bs:bs+blowfishBlockSize], encrypted[bs:bs+blowfishBlockSize])
+		}
+
+		raw = []byte(strings.TrimRight(string(raw), "\x00"))
+	}
+
+	body := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(raw, &body))
+	return body
+}
+
+func setupLegacyClientTest(t *testing.T, handlers map[string]legacyHandler) (*legacyClient, *httptest.Server) {
+	c := NewLegacyClient()
+
+	if _, ok := handlers["auth.partnerLogin"]; !ok {
+		expectLegacyPartnerLogin(handlers, time.Now())
+	}
+
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Query().Get("method")
+		body := decodeLegacyRequest(t, r)
+
+		if method != "auth.partnerLogin" && method != "auth.userLogin" {
+			assert.Equal(t, testLegacyAuthToken, r.URL.Query().Get("auth_token"), "Auth Token Set")
+			assert.Equal(t, testLegacyPartnerID, r.URL.Query().Get("partner_id"), "Partner ID Set")
+			assert.Equal(t, testLegacyUserID, r.URL.Query().Get("user_id"), "User ID Set")
+			assert.Equal(t, testLegacyAuthToken, body["userAuthToken"], "User Auth Token Set")
+		}
+
+		h, ok := handlers[method]
+		if !ok {
+			testutil.MarshalResponse(t, http.StatusOK, w, LegacyResponse{Stat: "fail", Code: 1, Message: "unknown method " + method})
+			return
+		}
+
+		result := h(t, r, body)
+		if resp, ok := result.(LegacyResponse); ok {
+			testutil.MarshalResponse(t, http.StatusOK, w, resp)
+			return
+		}
+
+		raw, err := json.Marshal(result)
+		require.NoError(t, err)
+		testutil.MarshalResponse(t, http.StatusOK, w, legacyResponseEnvelope{
+			LegacyResponse: LegacyResponse{Stat: "ok"},
+			Result:         raw,
+		})
+	}))
+
+	c.log = testutil.NopLogger()
+	c.legacyURL = sv.URL
+	c.api = sv.Client()
+	c.retry.baseDelay = time.Millisecond
+	c.retry.maxDelay = 10 * time.Millisecond
+
+	return c, sv
+}
+
+func expectLegacyPartnerLogin(handlers map[string]legacyHandler, serverTime time.Time) {
+	handlers["auth.partnerLogin"] = func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+		return LegacyPartnerLoginResponseResult{
+			EncryptedSyncTime: legacyEncryptedSyncTime(serverTime.Unix()),
+			PartnerID:         testLegacyPartnerID,
+			PartnerAuthToken:  "partnerAuthToken",
+		}
+	}
+}
+
+func expectLegacyLogin(handlers map[string]legacyHandler) {
+	handlers["auth.userLogin"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "un", body["username"])
+		assert.Equal(t, "pw", body["password"])
+
+		return LegacyUserLoginResponseResult{
+			UserID:        testLegacyUserID,
+			UserAuthToken: testLegacyAuthToken,
+		}
+	}
+}
+
+func TestLegacyClient_Login(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	s, err := sut.Session()
+	require.NoError(t, err)
+	assert.Equal(t, Session{Username: "un", AuthToken: testLegacyAuthToken, UserID: testLegacyUserID}, s)
+}
+
+func TestLegacyClient_GetStations(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["user.getStationList"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, true, body["includeStationArtUrl"])
+
+		return LegacyStationListResponse{Stations: []LegacyStation{
+			{StationID: "1", StationToken: "token1", StationName: "Foo", ArtURL: "art", DateCreated: LegacyDate{Time: 1000}},
+			{StationID: "2", StationToken: "token2", StationName: "Bar"},
+		}}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	stations, err := sut.GetStations(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stations, 2)
+
+	assert.Equal(t, "token1", stations[0].ID)
+	assert.Equal(t, "1", stations[0].PandoraId)
+	assert.Equal(t, "Foo", stations[0].Name)
+	assert.Equal(t, time.Unix(1, 0).UTC(), stations[0].CreatedAt)
+	assert.Equal(t, []pandora.Art{{URL: "art"}}, stations[0].Art)
+	assert.Empty(t, stations[1].Art)
+}
+
+func TestLegacyClient_GetMoreTracks(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["station.getPlaylist"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "station", body["stationToken"])
+
+		return LegacyPlaylistResponse{Items: []LegacyPlaylistItem{
+			{AdToken: "ad"},
+			{
+				TrackToken:    "track",
+				StationID:     "1",
+				ArtistName:    "Artist",
+				AlbumName:     "Album",
+				SongName:      "Song",
+				SongRating:    1,
+				TrackGain:     "-1.5",
+				TrackLength:   180,
+				AllowFeedback: true,
+				AudioURLMap: map[string]LegacyAudioURL{
+					legacyAudioQualityHigh: {Encoding: "aacplus", AudioURL: "http://audio"},
+				},
+			},
+		}}
+	}
+	handlers["station.addFeedback"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "station", body["stationToken"])
+		assert.Equal(t, "track", body["trackToken"])
+		assert.Equal(t, true, body["isPositive"])
+
+		return LegacyFeedback{FeedbackID: "feedback", IsPositive: true, SongName: "Song", ArtistName: "Artist"}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	fragment, err := sut.GetMoreTracks(context.Background(), "station", FragmentRequestReasonNormal)
+	require.NoError(t, err)
+	tracks := fragment.Tracks
+	require.Len(t, tracks, 1)
+
+	track := tracks[0]
+	assert.Equal(t, "track", track.TrackToken)
+	assert.Equal(t, "station", track.StationId)
+	assert.Equal(t, "Artist", track.ArtistName)
+	assert.Equal(t, "Album", track.AlbumTitle)
+	assert.Equal(t, "Song", track.SongTitle)
+	assert.EqualValues(t, pandora.TrackRatingLike, track.Rating)
+	assert.Equal(t, -1.5, track.FileGain)
+	assert.Equal(t, "http://audio", track.AudioUrl)
+	assert.Equal(t, pandora.AudioFormatAACPlus, track.AudioEncoding)
+	assert.True(t, track.AllowSkip)
+	assert.False(t, track.AllowSkipTrackWithoutLimit, "Skip limits must be tracked by the controller")
+
+	feedback, err := sut.AddFeedback(context.Background(), "track", true)
+	require.NoError(t, err)
+	assert.Equal(t, pandora.Feedback{
+		ID:         "feedback",
+		StationId:  "station",
+		IsPositive: true,
+		SongTitle:  "Song",
+		ArtistName: "Artist",
+	}, feedback)
+
+	_, err = sut.AddFeedback(context.Background(), "unknown", true)
+	assert.Error(t, err)
+}
+
+func TestLegacyClient_GetNarrative(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["track.explainTrack"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "track", body["trackToken"])
+
+		return LegacyExplainTrackResponse{Explanations: []LegacyExplanation{
+			{FocusTraitName: "foo"},
+			{FocusTraitName: "bar"},
+			{FocusTraitName: "baz"},
+		}}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	narrative, err := sut.GetNarrative(context.Background(), "station", "track")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"foo", "bar", "baz"}, narrative.FocusTraits)
+	assert.Equal(t, "Based on what you've told us so far, we're playing this track because it features foo, bar and baz.", narrative.Paragraph)
+}
+
+func TestLegacyClient_Reauthenticate(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+
+	logins := 0
+	login := handlers["auth.userLogin"]
+	handlers["auth.userLogin"] = func(t *testing.T, r *http.Request, body map[string]interface{}) interface{} {
+		logins++
+		return login(t, r, body)
+	}
+
+	calls := 0
+	handlers["user.sleepSong"] = func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+		calls++
+		if calls == 1 {
+			return LegacyResponse{Stat: "fail", Code: 1001, Message: "INVALID_AUTH_TOKEN"}
+		}
+
+		return struct{}{}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+	require.NoError(t, sut.AddTired(context.Background(), "track"))
+
+	assert.Equal(t, 2, logins)
+	assert.Equal(t, 2, calls)
+}
+
+func TestLegacyClient_ResumeSession(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		handlers := map[string]legacyHandler{
+			"user.getStationListChecksum": func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+				return struct{}{}
+			},
+		}
+
+		sut, server := setupLegacyClientTest(t, handlers)
+		defer server.Close()
+
+		require.NoError(t, sut.ResumeSession(context.Background(), Session{
+			Username:  "un",
+			AuthToken: testLegacyAuthToken,
+			UserID:    testLegacyUserID,
+		}))
+
+		s, err := sut.Session()
+		require.NoError(t, err)
+		assert.Equal(t, "un", s.Username)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		handlers := map[string]legacyHandler{
+			"user.getStationListChecksum": func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+				return LegacyResponse{Stat: "fail", Code: 1001, Message: "INVALID_AUTH_TOKEN"}
+			},
+		}
+
+		sut, server := setupLegacyClientTest(t, handlers)
+		defer server.Close()
+
+		err := sut.ResumeSession(context.Background(), Session{
+			Username:  "un",
+			AuthToken: testLegacyAuthToken,
+			UserID:    testLegacyUserID,
+		})
+		require.True(t, errors.Is(err, ErrInvalidAuth))
+
+		_, err = sut.Session()
+		assert.Error(t, err)
+	})
+
+	t.Run("NotLegacy", func(t *testing.T) {
+		sut, server := setupLegacyClientTest(t, map[string]legacyHandler{})
+		defer server.Close()
+
+		assert.Error(t, sut.ResumeSession(context.Background(), Session{Username: "un", AuthToken: "rest"}))
+	})
+}
+
+func TestLegacyClient_ManageStations(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["station.createStation"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "musicToken", body["musicToken"])
+
+		return LegacyStation{StationID: "1", StationToken: "token1", StationName: "Foo"}
+	}
+	handlers["station.renameStation"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "token1", body["stationToken"])
+		assert.Equal(t, "Bar", body["stationName"])
+
+		return LegacyStation{StationID: "1", StationToken: "token1", StationName: "Bar"}
+	}
+	handlers["station.deleteStation"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "token1", body["stationToken"])
+
+		return struct{}{}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	station, err := sut.CreateStation(context.Background(), "musicToken")
+	require.NoError(t, err)
+	assert.Equal(t, "token1", station.ID)
+	assert.Equal(t, "Foo", station.Name)
+
+	require.NoError(t, sut.RenameStation(context.Background(), station.ID, "Bar"))
+	require.NoError(t, sut.DeleteStation(context.Background(), station.ID))
+}
+
+func TestLegacyClient_Search(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["music.search"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "dummy", body["searchText"])
+		assert.Equal(t, true, body["includeGenreStations"])
+
+		return LegacySearchResponse{
+			Artists:       []LegacySearchResult{{MusicToken: "R1", ArtistName: "Artist"}},
+			Songs:         []LegacySearchResult{{MusicToken: "S1", SongName: "Song", ArtistName: "Artist"}},
+			GenreStations: []LegacySearchResult{{MusicToken: "G1", StationName: "Genre"}},
+		}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	result, err := sut.Search(context.Background(), "dummy")
+	require.NoError(t, err)
+	assert.Equal(t, pandora.SearchResults{
+		Artists: []pandora.SearchResult{{PandoraId: "R1", Type: pandora.SearchResultTypeArtist, Name: "Artist"}},
+		Tracks:  []pandora.SearchResult{{PandoraId: "S1", Type: pandora.SearchResultTypeTrack, Name: "Song", ArtistName: "Artist"}},
+		Genres:  []pandora.SearchResult{{PandoraId: "G1", Type: pandora.SearchResultTypeGenre, Name: "Genre"}},
+	}, result)
+}
+
+func TestLegacyPlaylistItem_ToTrack_AdditionalAudioURL(t *testing.T) {
+	item := LegacyPlaylistItem{
+		TrackToken:         "track",
+		AudioURLMap:        map[string]LegacyAudioURL{legacyAudioQualityHigh: {Encoding: "aacplus", AudioURL: "http://high"}},
+		AdditionalAudioURL: json.RawMessage(`"http://additional"`),
+	}
+
+	for _, f := range []pandora.AudioFormat{pandora.AudioFormatMP3, pandora.AudioFormatPremiumMP3} {
+		t.Run(string(f), func(t *testing.T) {
+			track, err := item.toTrack("station", f)
+			require.NoError(t, err)
+			assert.Equal(t, "http://additional", track.AudioUrl)
+			assert.Equal(t, f, track.AudioEncoding)
+		})
+	}
+
+	track, err := item.toTrack("station", pandora.AudioFormatAACPlus)
+	require.NoError(t, err)
+	assert.Equal(t, "http://high", track.AudioUrl)
+	assert.Equal(t, pandora.AudioFormatAACPlus, track.AudioEncoding)
+}
+
+func TestLegacyClient_CreateStationFromTrack(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["station.getPlaylist"] = func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+		return LegacyPlaylistResponse{Items: []LegacyPlaylistItem{{
+			TrackToken:  "track",
+			AudioURLMap: map[string]LegacyAudioURL{legacyAudioQualityHigh: {AudioURL: "http://audio"}},
+		}}}
+	}
+	handlers["station.createStation"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "track", body["trackToken"])
+		assert.Equal(t, "song", body["musicType"])
+		assert.NotContains(t, body, "musicToken")
+
+		return LegacyStation{StationToken: "token1", StationName: "Song Radio"}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	fragment, err := sut.GetMoreTracks(context.Background(), "station", FragmentRequestReasonNormal)
+	require.NoError(t, err)
+	tracks := fragment.Tracks
+	require.Len(t, tracks, 1)
+	require.True(t, tracks[0].AllowStartStationFromTrack)
+
+	station, err := sut.CreateStation(context.Background(), tracks[0].PandoraId)
+	require.NoError(t, err)
+	assert.Equal(t, "token1", station.ID)
+}
+
+func TestLegacyClient_StationSeeds(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["station.getStation"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "token1", body["stationToken"])
+		assert.Equal(t, true, body["includeExtendedAttributes"])
+
+		return LegacyStationDetails{
+			LegacyStation: LegacyStation{StationToken: "token1", StationName: "Foo"},
+			Music: LegacyStationMusic{
+				Artists: []LegacySeed{{SeedID: "s1", MusicToken: "R1", ArtistName: "Artist"}},
+				Songs:   []LegacySeed{{SeedID: "s2", MusicToken: "S1", ArtistName: "Artist", SongName: "Song"}},
+			},
+			Feedback: LegacyStationFeedback{TotalThumbsUp: 4, TotalThumbsDown: 2},
+		}
+	}
+	handlers["station.addMusic"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "token1", body["stationToken"])
+		assert.Equal(t, "S2", body["musicToken"])
+
+		return LegacySeed{SeedID: "s3", MusicToken: "S2", ArtistName: "Artist", SongName: "Other Song"}
+	}
+	handlers["station.deleteMusic"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "s3", body["seedId"])
+
+		return struct{}{}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	details, err := sut.GetStationDetails(context.Background(), "token1")
+	require.NoError(t, err)
+	assert.Equal(t, "Foo", details.Name)
+	assert.Equal(t, 4, details.PositiveFeedbackCount)
+	assert.Equal(t, 2, details.NegativeFeedbackCount)
+	assert.Equal(t, []pandora.StationSeed{
+		{SeedId: "s1", PandoraId: "R1", Type: pandora.SearchResultTypeArtist, Name: "Artist", ArtistName: "Artist"},
+		{SeedId: "s2", PandoraId: "S1", Type: pandora.SearchResultTypeTrack, Name: "Song", ArtistName: "Artist"},
+	}, details.Seeds)
+	assert.Equal(t, details.Seeds[0], details.InitialSeed)
+
+	seed, err := sut.AddSeed(context.Background(), "token1", "S2")
+	require.NoError(t, err)
+	assert.Equal(t, pandora.SearchResultTypeTrack, seed.Type)
+	assert.Equal(t, "Other Song", seed.Name)
+
+	require.NoError(t, sut.RemoveSeed(context.Background(), "token1", seed.SeedId))
+}
+
+func TestLegacyClient_StationFeedback(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["station.getStation"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "token1", body["stationToken"])
+
+		return LegacyStationDetails{
+			LegacyStation: LegacyStation{StationToken: "token1"},
+			Feedback: LegacyStationFeedback{
+				ThumbsUp:   []LegacyFeedback{{FeedbackID: "up", IsPositive: true, SongName: "Loved"}},
+				ThumbsDown: []LegacyFeedback{{FeedbackID: "down", SongName: "Banned"}},
+			},
+		}
+	}
+	handlers["station.deleteFeedback"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "up", body["feedbackId"])
+
+		return struct{}{}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	var feedback []pandora.Feedback
+	require.NoError(t, sut.GetStationFeedback(context.Background(), "token1", func(page []pandora.Feedback) error {
+		feedback = append(feedback, page...)
+		return nil
+	}))
+
+	assert.Equal(t, []pandora.Feedback{
+		{ID: "up", StationId: "token1", IsPositive: true, SongTitle: "Loved"},
+		{ID: "down", StationId: "token1", SongTitle: "Banned"},
+	}, feedback)
+
+	require.NoError(t, sut.DeleteFeedback(context.Background(), "up"))
+}
+
+func TestLegacyClient_Bookmarks(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["bookmark.addSongBookmark"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "track", body["trackToken"])
+		return LegacyBookmark{BookmarkToken: "b1", MusicToken: "S1", SongName: "Song", ArtistName: "Artist", AlbumName: "Album"}
+	}
+	handlers["bookmark.addArtistBookmark"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		assert.Equal(t, "track", body["trackToken"])
+		return LegacyBookmark{BookmarkToken: "b2", MusicToken: "R1", ArtistName: "Artist"}
+	}
+	handlers["user.getBookmarks"] = func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+		return LegacyBookmarksResponse{
+			Artists: []LegacyBookmark{{BookmarkToken: "b2", MusicToken: "R1", ArtistName: "Artist"}},
+			Songs:   []LegacyBookmark{{BookmarkToken: "b1", MusicToken: "S1", SongName: "Song", ArtistName: "Artist", AlbumName: "Album"}},
+		}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	song := pandora.Bookmark{ID: "b1", Type: pandora.BookmarkTypeSong, PandoraId: "S1", SongTitle: "Song", ArtistName: "Artist", AlbumTitle: "Album"}
+	artist := pandora.Bookmark{ID: "b2", Type: pandora.BookmarkTypeArtist, PandoraId: "R1", ArtistName: "Artist"}
+
+	bookmark, err := sut.BookmarkSong(context.Background(), "track")
+	require.NoError(t, err)
+	assert.Equal(t, song, bookmark)
+
+	bookmark, err = sut.BookmarkArtist(context.Background(), "track")
+	require.NoError(t, err)
+	assert.Equal(t, artist, bookmark)
+
+	bookmarks, err := sut.GetBookmarks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, pandora.Bookmarks{
+		Artists: []pandora.Bookmark{artist},
+		Songs:   []pandora.Bookmark{song},
+	}, bookmarks)
+}
+
+func TestLegacyClient_GetGenreCategories(t *testing.T) {
+	handlers := map[string]legacyHandler{}
+	expectLegacyLogin(handlers)
+	handlers["station.getGenreStations"] = func(t *testing.T, _ *http.Request, _ map[string]interface{}) interface{} {
+		return LegacyGenreStationsResponse{
+			Categories: []LegacyGenreCategory{
+				{CategoryName: "Rock", Stations: []LegacyGenreStation{{StationToken: "G1", StationName: "Classic Rock"}}},
+			},
+		}
+	}
+
+	sut, server := setupLegacyClientTest(t, handlers)
+	defer server.Close()
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	categories, err := sut.GetGenreCategories(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []pandora.GenreCategory{
+		{Name: "Rock", Stations: []pandora.GenreStation{{PandoraId: "G1", Name: "Classic Rock"}}},
+	}, categories)
+}
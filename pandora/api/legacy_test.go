@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func TestClient_ParseLegacySyncTime(t *testing.T) {
+	local := time.Unix(1600000000, 0)
+	clock := &fakeClock{now: local}
+
+	sut := NewClient()
+	sut.log = testutil.NopLogger()
+	sut.now = clock.Now
+
+	for _, tt := range []struct {
+		name   string
+		server time.Time
+		offset time.Duration
+	}{
+		{name: "InSync", server: local, offset: 0},
+		{name: "Ahead", server: local.Add(90 * time.Second), offset: 90 * time.Second},
+		{name: "Behind", server: local.Add(-time.Hour), offset: -time.Hour},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := sut.parseLegacySyncTime(legacyEncryptedSyncTime(tt.server.Unix()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.offset, offset)
+			assert.Equal(t, tt.server.Unix(), sut.legacySyncTime(offset))
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := sut.parseLegacySyncTime("not hex")
+		assert.Error(t, err)
+
+		_, err = sut.parseLegacySyncTime("")
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_LegacySyncTime(t *testing.T) {
+	local := time.Unix(1600000000, 0)
+	server := local.Add(-42 * time.Minute)
+	clock := &fakeClock{now: local}
+
+	var syncTimes []int64
+	recordSyncTime := func(t *testing.T, body map[string]interface{}) {
+		v, ok := body["syncTime"].(float64)
+		require.True(t, ok, "syncTime set")
+		syncTimes = append(syncTimes, int64(v))
+	}
+
+	handlers := map[string]legacyHandler{}
+	expectLegacyPartnerLogin(handlers, server)
+	handlers["auth.userLogin"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		recordSyncTime(t, body)
+		return LegacyUserLoginResponseResult{
+			UserID:        testLegacyUserID,
+			UserAuthToken: testLegacyAuthToken,
+		}
+	}
+	handlers["user.sleepSong"] = func(t *testing.T, _ *http.Request, body map[string]interface{}) interface{} {
+		recordSyncTime(t, body)
+		return struct{}{}
+	}
+
+	sut, sv := setupLegacyClientTest(t, handlers)
+	defer sv.Close()
+	sut.now = clock.Now
+
+	require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+	// Long-lived sessions should keep tracking pandora's clock instead of
+	// re-using the sync time from login
+	clock.Advance(3 * time.Hour)
+	require.NoError(t, sut.AddTired(context.Background(), "track"))
+
+	clock.Advance(time.Minute)
+	require.NoError(t, sut.AddTired(context.Background(), "track"))
+
+	assert.Equal(t, []int64{
+		server.Unix(),
+		server.Add(3 * time.Hour).Unix(),
+		server.Add(3*time.Hour + time.Minute).Unix(),
+	}, syncTimes)
+}
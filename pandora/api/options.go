@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Option customizes a client created by NewClient or NewLegacyClient
+type Option func(c *client)
+
+// WithBaseURL points the REST API at base instead of https://www.pandora.com
+func WithBaseURL(base string) Option {
+	return func(c *client) {
+		base = strings.TrimSuffix(base, "/")
+
+		c.apiURL = fmt.Sprintf("%s/api", base)
+		c.csrfURL = base
+	}
+}
+
+// WithLegacyURL points the legacy JSON API at endpoint instead of
+// https://tuner.pandora.com/services/json/
+func WithLegacyURL(endpoint string) Option {
+	return func(c *client) {
+		c.legacyURL = endpoint
+	}
+}
+
+// WithHTTPClient makes requests with h instead of the default client. The
+// request-timeout setting is not applied to h.
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *client) {
+		c.api = h
+	}
+}
+
+// WithTransport makes requests through rt, for example to record or replay
+// them. The http client is copied first, so a client passed to WithHTTPClient
+// is left as it was.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *client) {
+		api := *c.api
+		api.Transport = rt
+
+		c.api = &api
+	}
+}
+
+// WithUserAgent sends ua as the User-Agent of every request
+func WithUserAgent(ua string) Option {
+	return func(c *client) {
+		c.userAgent = ua
+	}
+}
+
+// WithLogger logs with log instead of the standard logger
+func WithLogger(log logrus.FieldLogger) Option {
+	return func(c *client) {
+		c.log = log
+	}
+}
+
+// ProxyTransport returns a transport that sends requests through the proxy at
+// proxyURL. The http, https, and socks5 schemes are supported. Without a
+// proxy, the default transport already honors HTTPS_PROXY and NO_PROXY.
+func ProxyTransport(proxyURL string) (http.RoundTripper, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse proxy url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected one of [http, https, socks5]", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy url %q has no host", proxyURL)
+	}
+
+	t := cleanhttp.DefaultPooledTransport()
+	t.Proxy = http.ProxyURL(u)
+
+	return t, nil
+}
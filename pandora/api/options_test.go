@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		sut := NewClient()
+
+		require.Equal(t, "https://www.pandora.com/api", sut.apiURL)
+		require.Equal(t, "https://www.pandora.com", sut.csrfURL)
+		require.Equal(t, legacyAPIEndpoint, sut.legacyURL)
+		require.Equal(t, defaultUserAgent, sut.userAgent)
+	})
+
+	t.Run("URLs", func(t *testing.T) {
+		sut := NewClient(WithBaseURL("http://localhost:1234/"), WithLegacyURL("http://localhost:1234/services/json/"))
+
+		require.Equal(t, "http://localhost:1234/api", sut.apiURL)
+		require.Equal(t, "http://localhost:1234", sut.csrfURL)
+		require.Equal(t, "http://localhost:1234/services/json/", sut.legacyURL)
+	})
+
+	t.Run("HTTP Client", func(t *testing.T) {
+		h := &http.Client{}
+		sut := NewClient(WithHTTPClient(h))
+
+		require.Same(t, h, sut.api)
+	})
+
+	t.Run("Transport Does Not Modify HTTP Client", func(t *testing.T) {
+		h := &http.Client{Timeout: time.Minute}
+		rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("dummy")
+		})
+
+		sut := NewClient(WithHTTPClient(h), WithTransport(rt))
+
+		require.Nil(t, h.Transport)
+		require.NotSame(t, h, sut.api)
+		require.NotNil(t, sut.api.Transport)
+		require.Equal(t, time.Minute, sut.api.Timeout)
+	})
+
+	t.Run("Transport And User Agent", func(t *testing.T) {
+		var seen []string
+		rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			seen = append(seen, r.Header.Get("User-Agent"))
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+		})
+
+		sut := NewClient(WithTransport(rt), WithUserAgent("mousiki-test"), WithLogger(testutil.NopLogger()))
+		sut.retry.baseDelay = 0
+
+		track := testutil.MakeTrack()
+		track.AudioReceiptURL = "http://pandora.invalid/receipt"
+
+		require.NoError(t, sut.ReportAudioReceipt(context.Background(), track))
+		require.Equal(t, []string{"mousiki-test"}, seen)
+	})
+
+	t.Run("Legacy Logger", func(t *testing.T) {
+		log := testutil.NopLogger()
+
+		require.NotEqual(t, log, NewLegacyClient().log)
+		require.Equal(t, log, NewLegacyClient(WithLogger(log)).log)
+	})
+}
+
+func TestProxyTransport(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		proxied := 0
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "http://pandora.invalid/receipt", r.URL.String())
+
+			proxied++
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer proxy.Close()
+
+		rt, err := ProxyTransport(proxy.URL)
+		require.NoError(t, err)
+
+		sut := NewClient(WithTransport(rt), WithLogger(testutil.NopLogger()))
+		track := testutil.MakeTrack()
+		track.AudioReceiptURL = "http://pandora.invalid/receipt"
+
+		require.NoError(t, sut.ReportAudioReceipt(context.Background(), track))
+		require.Equal(t, 1, proxied)
+	})
+
+	t.Run("SOCKS5", func(t *testing.T) {
+		rt, err := ProxyTransport("socks5://127.0.0.1:1080")
+		require.NoError(t, err)
+
+		proxyURL, err := rt.(*http.Transport).Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "www.pandora.com"}})
+		require.NoError(t, err)
+		require.Equal(t, "socks5://127.0.0.1:1080", proxyURL.String())
+	})
+
+	for _, proxyURL := range []string{"ftp://127.0.0.1:21", "127.0.0.1:8080", "http://"} {
+		proxyURL := proxyURL
+		t.Run("Invalid "+proxyURL, func(t *testing.T) {
+			_, err := ProxyTransport(proxyURL)
+			require.Error(t, err)
+		})
+	}
+}
@@ -5,8 +5,12 @@ import "github.com/nlowe/mousiki/pandora"
 type FragmentRequestReason string
 
 const (
+	// FragmentRequestReasonNormal is used when the queue runs low while tracks
+	// are played to completion
 	FragmentRequestReasonNormal FragmentRequestReason = "Normal"
-	FragmentRequestReasonString FragmentRequestReason = "Skip"
+	// FragmentRequestReasonSkip is used when the queue runs low because the
+	// user skipped a track
+	FragmentRequestReasonSkip FragmentRequestReason = "Skip"
 )
 
 type GetPlaylistFragmentRequest struct {
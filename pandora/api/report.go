@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nlowe/mousiki/pandora"
+)
+
+// TrackReporter lets pandora know what happened to the tracks it gave us.
+// Pandora uses these reports for royalty accounting and to enforce skip limits.
+type TrackReporter interface {
+	// ReportAudioReceipt tells pandora that playback of the track has started
+	ReportAudioReceipt(ctx context.Context, t pandora.Track) error
+	// ReportSkip tells pandora that the track was skipped
+	ReportSkip(ctx context.Context, t pandora.Track) error
+}
+
+func (c *client) ReportAudioReceipt(ctx context.Context, t pandora.Track) error {
+	if err := c.report(ctx, t.AudioReceiptURL); err != nil {
+		return fmt.Errorf("ReportAudioReceipt: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) ReportSkip(ctx context.Context, t pandora.Track) error {
+	if err := c.report(ctx, t.AudioSkipURL); err != nil {
+		return fmt.Errorf("ReportSkip: %w", err)
+	}
+
+	return nil
+}
+
+// report requests one of the reporting URLs attached to a track. Not every
+// track has them, so there's nothing to do if the URL is empty.
+func (c *client) report(ctx context.Context, reportURL string) error {
+	if reportURL == "" {
+		return nil
+	}
+
+	log := c.log.WithField("url", reportURL)
+
+	resp, err := c.withRetry(ctx, retryIdempotent, log, func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reportURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("User-Agent", c.userAgent)
+		return c.api.Do(req)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	defer mustClose(resp.Body)
+	return checkHttpCode(resp)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func setupReportTest(t *testing.T, m *http.ServeMux) (*client, *httptest.Server) {
+	c := NewClient()
+	sv := httptest.NewServer(m)
+
+	c.log = testutil.NopLogger()
+	c.api = sv.Client()
+	c.retry.baseDelay = 0
+
+	return c, sv
+}
+
+func TestClient_ReportAudioReceipt(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		called := 0
+
+		m := http.NewServeMux()
+		m.HandleFunc("/receipt", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, defaultUserAgent, r.Header.Get("User-Agent"))
+
+			called++
+			w.WriteHeader(http.StatusOK)
+		})
+
+		sut, server := setupReportTest(t, m)
+		defer server.Close()
+
+		track := testutil.MakeTrack()
+		track.AudioReceiptURL = fmt.Sprintf("%s/receipt", server.URL)
+
+		require.NoError(t, sut.ReportAudioReceipt(context.Background(), track))
+		require.Equal(t, 1, called)
+	})
+
+	t.Run("NoURL", func(t *testing.T) {
+		sut, server := setupReportTest(t, http.NewServeMux())
+		defer server.Close()
+
+		require.NoError(t, sut.ReportAudioReceipt(context.Background(), pandora.Track{}))
+	})
+
+	t.Run("HttpError", func(t *testing.T) {
+		m := http.NewServeMux()
+		m.HandleFunc("/receipt", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		sut, server := setupReportTest(t, m)
+		defer server.Close()
+
+		track := testutil.MakeTrack()
+		track.AudioReceiptURL = fmt.Sprintf("%s/receipt", server.URL)
+
+		require.Error(t, sut.ReportAudioReceipt(context.Background(), track))
+	})
+}
+
+func TestClient_ReportSkip(t *testing.T) {
+	called := 0
+
+	m := http.NewServeMux()
+	m.HandleFunc("/skip", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+
+		called++
+		w.WriteHeader(http.StatusOK)
+	})
+
+	sut, server := setupReportTest(t, m)
+	defer server.Close()
+
+	track := testutil.MakeTrack()
+	track.AudioSkipURL = fmt.Sprintf("%s/skip", server.URL)
+
+	require.NoError(t, sut.ReportSkip(context.Background(), track))
+	require.Equal(t, 1, called)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// retryPolicy controls when a failed request may be attempted again
+type retryPolicy int
+
+const (
+	// retryUnsent only retries requests that provably never reached pandora.
+	// Use this for requests that are not safe to repeat, like adding feedback.
+	retryUnsent retryPolicy = iota
+	// retryIdempotent retries requests that failed because of a connection
+	// error or a transient server error (5xx or 429)
+	retryIdempotent
+)
+
+const (
+	defaultRetryAttempts  = 4
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 10 * time.Second
+)
+
+type retryConfig struct {
+	attempts  int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+// withRetry calls send until it succeeds, the retry policy says the failure is
+// not worth retrying, we run out of attempts, or ctx is done. Delays between
+// attempts grow exponentially with jitter, unless pandora tells us how long to
+// wait with a Retry-After header.
+func (c *client) withRetry(
+	ctx context.Context,
+	policy retryPolicy,
+	log logrus.FieldLogger,
+	send func(ctx context.Context) (*http.Response, error),
+) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		// Track whether or not we started writing the request. If we never
+		// got that far, pandora can't have seen it.
+		sent := false
+		traced := httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+			WroteHeaders: func() {
+				sent = true
+			},
+		})
+
+		resp, err := send(traced)
+		if attempt >= c.retry.attempts || !shouldRetry(policy, resp, err, sent) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := c.retryDelay(attempt, resp)
+		entry := log.WithFields(logrus.Fields{
+			"attempt": attempt,
+			"delay":   delay,
+		})
+
+		if err != nil {
+			entry = entry.WithError(err)
+		} else {
+			entry = entry.WithField("status", resp.Status)
+			mustClose(resp.Body)
+		}
+
+		entry.Warn("Request failed, retrying")
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func shouldRetry(policy retryPolicy, resp *http.Response, err error, sent bool) bool {
+	if policy == retryUnsent {
+		return err != nil && !sent
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+func (c *client) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	delay := c.retry.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.retry.maxDelay {
+		delay = c.retry.maxDelay
+	}
+
+	// Equal jitter: wait somewhere between half and all of the delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+
+		return d, true
+	}
+
+	return 0, false
+}
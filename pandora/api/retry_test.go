@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// failFirst fails the first n requests to path with err, optionally pretending
+// the request made it onto the wire first
+func failFirst(c *client, n int, path string, sent bool) *int {
+	calls := 0
+	next := c.api.Transport
+
+	c.api.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != path {
+			return next.RoundTrip(r)
+		}
+
+		calls++
+		if calls <= n {
+			if sent {
+				if trace := httptrace.ContextClientTrace(r.Context()); trace != nil && trace.WroteHeaders != nil {
+					trace.WroteHeaders()
+				}
+			}
+
+			return nil, errors.New("connection reset by peer")
+		}
+
+		return next.RoundTrip(r)
+	})
+
+	return &calls
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Run("Server Errors", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		calls := 0
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/getStations", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			testutil.MarshalResponse(t, http.StatusOK, w, &StationResponse{
+				TotalStations: 1,
+				Stations:      []pandora.Station{{Name: "Test Station"}},
+			})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		stations, err := sut.GetStations(context.Background())
+		require.NoError(t, err)
+		require.Len(t, stations, 1)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("Gives Up", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		calls := 0
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/narrative", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.GetNarrative(context.Background(), "foo", "bar")
+		require.Error(t, err)
+		require.Equal(t, defaultRetryAttempts, calls)
+	})
+
+	t.Run("Does Not Retry Client Errors", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		calls := 0
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/narrative", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.GetNarrative(context.Background(), "foo", "bar")
+		require.Error(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("Connection Errors", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/narrative", func(w http.ResponseWriter, r *http.Request) {
+			testutil.MarshalResponse(t, http.StatusOK, w, &pandora.Narrative{})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		calls := failFirst(sut, 2, "/api/v1/playlist/narrative", true)
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.GetNarrative(context.Background(), "foo", "bar")
+		require.NoError(t, err)
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("Feedback Retries Unsent Requests", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/addFeedback", func(w http.ResponseWriter, r *http.Request) {
+			testutil.MarshalResponse(t, http.StatusOK, w, &AddFeedbackResponse{})
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		calls := failFirst(sut, 1, "/api/v1/station/addFeedback", false)
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.AddFeedback(context.Background(), "foo", true)
+		require.NoError(t, err)
+		require.Equal(t, 2, *calls)
+	})
+
+	t.Run("Feedback Does Not Retry Sent Requests", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		calls := failFirst(sut, 1, "/api/v1/station/addFeedback", true)
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.AddFeedback(context.Background(), "foo", true)
+		require.Error(t, err)
+		require.Equal(t, 1, *calls)
+	})
+
+	t.Run("Feedback Does Not Retry Server Errors", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		calls := 0
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/station/addFeedback", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+		_, err := sut.AddFeedback(context.Background(), "foo", true)
+		require.Error(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("Bounded By Context", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		calls := 0
+
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+		m.HandleFunc("/api/v1/playlist/narrative", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		sut, server, _ := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := sut.GetNarrative(ctx, "foo", "bar")
+		require.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline exceeded, got %v", err)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("")
+	require.False(t, ok)
+	require.Zero(t, d)
+
+	d, ok = parseRetryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.Zero(t, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.InDelta(t, time.Hour, d, float64(5*time.Second))
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
+
+func TestClient_RetryDelay(t *testing.T) {
+	sut := NewClient()
+
+	for attempt := 1; attempt < 10; attempt++ {
+		d := sut.retryDelay(attempt, nil)
+		require.True(t, d >= defaultRetryBaseDelay/2, "attempt %d: %s", attempt, d)
+		require.True(t, d <= defaultRetryMaxDelay, "attempt %d: %s", attempt, d)
+	}
+}
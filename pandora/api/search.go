@@ -0,0 +1,19 @@
+package api
+
+import "github.com/nlowe/mousiki/pandora"
+
+type SearchRequest struct {
+	Query            string                     `json:"query"`
+	Types            []pandora.SearchResultType `json:"types"`
+	Start            int                        `json:"start"`
+	Count            int                        `json:"count"`
+	Annotate         bool                       `json:"annotate"`
+	AnnotationRecipe string                     `json:"annotationRecipe"`
+}
+
+// SearchResponse lists the pandora IDs of the results in order of relevance.
+// Details about each result are in Annotations.
+type SearchResponse struct {
+	Results     []string                        `json:"results"`
+	Annotations map[string]pandora.SearchResult `json:"annotations"`
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Session holds everything needed to resume a previous login without
+// prompting for credentials again
+type Session struct {
+	Username  string `json:"username"`
+	AuthToken string `json:"authToken"`
+	CSRFToken string `json:"csrfToken"`
+
+	// UserID is only set for sessions created by a legacy login
+	UserID string `json:"userId,omitempty"`
+}
+
+// Session returns the current login session, or an error if the client has
+// not logged in yet
+func (c *client) Session() (Session, error) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	if c.authToken == "" {
+		return Session{}, errNotLoggedIn
+	}
+
+	result := Session{
+		Username:  c.username,
+		AuthToken: c.authToken,
+		UserID:    c.legacyUserID,
+	}
+
+	if c.csrfToken != nil {
+		result.CSRFToken = c.csrfToken.Value
+	}
+
+	return result, nil
+}
+
+// ResumeSession restores a session returned by Session and validates it with
+// pandora by exchanging the stored auth token for a fresh one
+func (c *client) ResumeSession(ctx context.Context, s Session) error {
+	c.log.WithField("username", s.Username).Debug("Attempting to resume session")
+
+	if s.AuthToken == "" {
+		return errors.New("resume session: no auth token")
+	}
+
+	if s.CSRFToken != "" {
+		c.csrfToken = &http.Cookie{
+			Name:  csrfCookieName,
+			Value: s.CSRFToken,
+		}
+	}
+
+	resp, err := c.post(ctx, retryUnsent, "/v1/auth/login", &LoginRequest{
+		ExistingAuthToken: s.AuthToken,
+		KeepLoggedIn:      true,
+	})
+
+	if err != nil {
+		return fmt.Errorf("resume session: %w", err)
+	}
+
+	defer mustClose(resp.Body)
+	if err := checkHttpCode(resp); err != nil {
+		return fmt.Errorf("resume session: %w", err)
+	}
+
+	payload := LoginResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("resume session: read response: %w", err)
+	}
+
+	if payload.AuthToken == "" {
+		return errors.New("resume session: pandora did not return an auth token")
+	}
+
+	// Without credentials the best we can do when the token expires is to try
+	// and exchange it again
+	c.setAuth(s.Username, payload.AuthToken, func(ctx context.Context) error {
+		current, err := c.Session()
+		if err != nil {
+			return err
+		}
+
+		return c.ResumeSession(ctx, current)
+	})
+
+	c.log.WithField("username", s.Username).Info("Resumed Session")
+	return nil
+}
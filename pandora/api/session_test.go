@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Session(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		expectLogin(t, m, authToken)
+
+		sut, server, csrfToken := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.Login(context.Background(), "un", "pw"))
+
+		s, err := sut.Session()
+		require.NoError(t, err)
+		require.Equal(t, Session{Username: "un", AuthToken: authToken, CSRFToken: csrfToken}, s)
+	})
+
+	t.Run("RequiresLogin", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), "")
+		defer server.Close()
+
+		_, err := sut.Session()
+		require.EqualError(t, err, "not logged in")
+	})
+}
+
+func TestClient_ResumeSession(t *testing.T) {
+	existingAuthToken := uuid.Must(uuid.NewRandom()).String()
+
+	t.Run("Valid", func(t *testing.T) {
+		authToken := uuid.Must(uuid.NewRandom()).String()
+		m := http.NewServeMux()
+		m.HandleFunc("/api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+			v := LoginRequest{}
+			testutil.UnmarshalRequest(t, r, &v)
+
+			assert.Equal(t, existingAuthToken, v.ExistingAuthToken)
+			assert.True(t, v.KeepLoggedIn)
+			assert.Empty(t, v.Password)
+
+			testutil.MarshalResponse(t, http.StatusOK, w, &LoginResponse{AuthToken: authToken})
+		})
+
+		sut, server, csrfToken := setupClientTest(t, m, authToken)
+		defer server.Close()
+
+		require.NoError(t, sut.ResumeSession(context.Background(), Session{
+			Username:  "un",
+			AuthToken: existingAuthToken,
+			CSRFToken: csrfToken,
+		}))
+
+		s, err := sut.Session()
+		require.NoError(t, err)
+		require.Equal(t, Session{Username: "un", AuthToken: authToken, CSRFToken: csrfToken}, s)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		m := http.NewServeMux()
+		m.HandleFunc("/api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		sut, server, csrfToken := setupClientTest(t, m, "")
+		defer server.Close()
+
+		require.Error(t, sut.ResumeSession(context.Background(), Session{
+			Username:  "un",
+			AuthToken: existingAuthToken,
+			CSRFToken: csrfToken,
+		}))
+
+		_, err := sut.Session()
+		require.Error(t, err)
+	})
+
+	t.Run("RequiresAuthToken", func(t *testing.T) {
+		sut, server, _ := setupClientTest(t, http.NewServeMux(), "")
+		defer server.Close()
+
+		require.EqualError(t, sut.ResumeSession(context.Background(), Session{}), "resume session: no auth token")
+	})
+}
@@ -7,8 +7,9 @@ type StationSortOrder string
 const StationSortOrderLastPlayed StationSortOrder = "lastPlayedTime"
 
 type StationRequest struct {
-	PageSize   int `json:"pageSize"`
-	StartIndex int `json:"startIndex"`
+	PageSize   int              `json:"pageSize"`
+	StartIndex int              `json:"startIndex"`
+	SortedBy   StationSortOrder `json:"sortedBy,omitempty"`
 }
 
 type StationResponse struct {
@@ -17,3 +18,37 @@ type StationResponse struct {
 	Index         int               `json:"index"`
 	Stations      []pandora.Station `json:"stations"`
 }
+
+type CreateStationRequest struct {
+	PandoraID string `json:"pandoraId"`
+}
+
+type UpdateStationRequest struct {
+	StationID string `json:"stationId"`
+	Name      string `json:"name"`
+}
+
+type RemoveStationRequest struct {
+	StationID string `json:"stationId"`
+}
+
+type StationDetailsRequest struct {
+	StationID        string `json:"stationId"`
+	IsCurrentStation bool   `json:"isCurrentStation"`
+}
+
+type AddSeedRequest struct {
+	StationID string `json:"stationId"`
+	PandoraID string `json:"pandoraId"`
+}
+
+type RemoveSeedRequest struct {
+	StationID string `json:"stationId"`
+	SeedID    string `json:"seedId"`
+}
+
+type GenreStationsRequest struct{}
+
+type GenreStationsResponse struct {
+	Categories []pandora.GenreCategory `json:"categories"`
+}
@@ -0,0 +1,46 @@
+package pandora
+
+import "fmt"
+
+type BookmarkType string
+
+const (
+	BookmarkTypeArtist BookmarkType = "artist"
+	BookmarkTypeSong   BookmarkType = "song"
+)
+
+// Bookmark is an artist or song that was saved to come back to later
+type Bookmark struct {
+	ID   string       `json:"bookmarkId"`
+	Type BookmarkType `json:"type"`
+	// PandoraId is passed to CreateStation to create a station from this
+	// bookmark
+	PandoraId string `json:"pandoraId"`
+
+	SongTitle  string `json:"songTitle"`
+	ArtistName string `json:"artistName"`
+	AlbumTitle string `json:"albumTitle"`
+}
+
+type Bookmarks struct {
+	Artists []Bookmark
+	Songs   []Bookmark
+}
+
+// All returns every bookmark, artists first followed by songs
+func (b Bookmarks) All() []Bookmark {
+	result := make([]Bookmark, 0, len(b.Artists)+len(b.Songs))
+	result = append(result, b.Artists...)
+	return append(result, b.Songs...)
+}
+
+func (b Bookmark) String() string {
+	switch b.Type {
+	case BookmarkTypeArtist:
+		return fmt.Sprintf("[Artist] %s", b.ArtistName)
+	case BookmarkTypeSong:
+		return fmt.Sprintf("[Song] %s - %s", b.SongTitle, b.ArtistName)
+	default:
+		return b.ArtistName
+	}
+}
@@ -0,0 +1,31 @@
+package pandora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmark_String(t *testing.T) {
+	for _, tt := range []struct {
+		sut      Bookmark
+		expected string
+	}{
+		{sut: Bookmark{Type: BookmarkTypeArtist, ArtistName: "DummyArtist"}, expected: "[Artist] DummyArtist"},
+		{sut: Bookmark{Type: BookmarkTypeSong, SongTitle: "DummySong", ArtistName: "DummyArtist"}, expected: "[Song] DummySong - DummyArtist"},
+		{sut: Bookmark{Type: "??", ArtistName: "Unknown"}, expected: "Unknown"},
+	} {
+		t.Run(tt.expected, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.sut.String())
+		})
+	}
+}
+
+func TestBookmarks_All(t *testing.T) {
+	sut := Bookmarks{
+		Artists: []Bookmark{{ID: "a"}},
+		Songs:   []Bookmark{{ID: "s"}},
+	}
+
+	require.Equal(t, []Bookmark{{ID: "a"}, {ID: "s"}}, sut.All())
+}
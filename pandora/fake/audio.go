@@ -0,0 +1,142 @@
+package fake
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sampleRate    = 22050
+	bitsPerSample = 16
+
+	// fadeLength keeps the tone from clicking when it starts and stops
+	fadeLength = 50 * time.Millisecond
+)
+
+// toneFor picks the pitch of the n-th track, walking up a pentatonic scale so
+// consecutive tracks are easy to tell apart
+func toneFor(n int) float64 {
+	scale := []int{0, 2, 4, 7, 9}
+	semitones := scale[n%len(scale)] + 12*((n/len(scale))%2)
+
+	return 220 * math.Pow(2, float64(semitones)/12)
+}
+
+// handleAudio serves a track as a WAV file containing a sine tone
+func (s *Server) handleAudio(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/audio/"), ".wav")
+
+	s.lock.Lock()
+	t, ok := s.tracks[token]
+	length := s.audioLength
+	s.lock.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	samples := int(length.Seconds() * sampleRate)
+	w.Header().Set("Content-Type", "audio/wav")
+	w.Header().Set("Content-Length", strconv.Itoa(44+samples*bitsPerSample/8))
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	buff := bufio.NewWriter(w)
+	if err := writeTone(buff, t.tone, samples); err != nil {
+		s.log.WithError(err).WithField("track", token).Debug("Failed to stream audio")
+		return
+	}
+
+	_ = buff.Flush()
+}
+
+// writeTone writes a mono 16-bit PCM WAV file with the specified number of
+// samples of a sine wave at freq Hz
+func writeTone(w io.Writer, freq float64, samples int) error {
+	dataSize := uint32(samples * bitsPerSample / 8)
+
+	header := []interface{}{
+		[4]byte{'R', 'I', 'F', 'F'},
+		36 + dataSize,
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(16),
+		uint16(1), // PCM
+		uint16(1), // Mono
+		uint32(sampleRate),
+		uint32(sampleRate * bitsPerSample / 8),
+		uint16(bitsPerSample / 8),
+		uint16(bitsPerSample),
+		[4]byte{'d', 'a', 't', 'a'},
+		dataSize,
+	}
+
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+
+	fade := int(fadeLength.Seconds() * sampleRate)
+	sample := make([]byte, bitsPerSample/8)
+	for i := 0; i < samples; i++ {
+		gain := 0.3
+		if i < fade {
+			gain *= float64(i) / float64(fade)
+		} else if samples-i < fade {
+			gain *= float64(samples-i) / float64(fade)
+		}
+
+		v := gain * math.Sin(2*math.Pi*freq*float64(i)/sampleRate)
+		binary.LittleEndian.PutUint16(sample, uint16(int16(v*math.MaxInt16)))
+
+		if _, err := w.Write(sample); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleReport counts the audio receipts and skips reported for tracks. Skips
+// count against the skip limit of the station the track was played on.
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/report/"), "/")
+	if r.Method != http.MethodGet || len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	kind, token := parts[0], parts[1]
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t, ok := s.tracks[token]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch kind {
+	case "receipt":
+		s.receipts[token]++
+	case "skip":
+		s.skipped[token]++
+		s.skips[t.StationId] = append(s.skips[t.StationId], s.now())
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
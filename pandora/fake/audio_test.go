@@ -0,0 +1,52 @@
+package fake
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Audio(t *testing.T) {
+	fake, sv, opts := setupFakeTest(t, WithAudioLength(time.Second))
+	defer sv.Close()
+
+	station := fake.AddStation("Fake Radio")
+
+	c := api.NewClient(opts...)
+	require.NoError(t, c.Login(context.Background(), "un", "pw"))
+
+	fragment, err := c.GetMoreTracks(context.Background(), station.ID, api.FragmentRequestReasonNormal)
+	require.NoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		resp, err := http.Get(fragment.Tracks[0].AudioUrl)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		raw, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Len(t, raw, 44+sampleRate*bitsPerSample/8)
+		require.Equal(t, "RIFF", string(raw[0:4]))
+		require.Equal(t, "WAVE", string(raw[8:12]))
+	})
+
+	t.Run("Unknown Track", func(t *testing.T) {
+		resp, err := http.Get(sv.URL + "/audio/nope.wav")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestToneFor(t *testing.T) {
+	require.Equal(t, 220.0, toneFor(0))
+	require.NotEqual(t, toneFor(1), toneFor(2), "Consecutive tracks should sound different")
+}
@@ -0,0 +1,341 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/nlowe/mousiki/pandora/api"
+	"golang.org/x/crypto/blowfish"
+)
+
+const (
+	blowfishBlockSize = 8
+
+	// The android partner keys the legacy client logs in with. Requests are
+	// encrypted with the first, the sync time with the second.
+	legacyRequestKey  = `6#26FRL$ZWD`
+	legacyResponseKey = `R=U!LH$O2B#`
+
+	legacyErrorCodeBadSyncTime = 13
+)
+
+var (
+	legacyRequestCipher  = mustCipher(legacyRequestKey)
+	legacyResponseCipher = mustCipher(legacyResponseKey)
+)
+
+func mustCipher(key string) *blowfish.Cipher {
+	c, err := blowfish.NewCipher([]byte(key))
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+type legacyResult struct {
+	api.LegacyResponse
+	Result interface{} `json:"result,omitempty"`
+}
+
+// legacyCall is an encrypted legacy request after the auth token, user ID, and
+// sync time were checked
+type legacyCall struct {
+	method string
+	body   []byte
+}
+
+type legacyChecksumResponse struct {
+	Checksum string `json:"checksum"`
+}
+
+func (s *Server) handleLegacy(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Query().Get("method")
+	s.log.WithField("method", method).Debug("Legacy Call")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeLegacyError(w, 0, err.Error())
+		return
+	}
+
+	if method == "auth.partnerLogin" {
+		s.legacyPartnerLogin(w, raw)
+		return
+	}
+
+	body, err := legacyDecrypt(raw)
+	if err != nil {
+		writeLegacyError(w, 0, err.Error())
+		return
+	}
+
+	var envelope struct {
+		SyncTime      int64  `json:"syncTime"`
+		UserAuthToken string `json:"userAuthToken"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		writeLegacyError(w, 0, err.Error())
+		return
+	}
+
+	if envelope.SyncTime == 0 {
+		writeLegacyError(w, legacyErrorCodeBadSyncTime, "missing sync time")
+		return
+	}
+
+	if method == "auth.userLogin" {
+		s.legacyUserLogin(w, r, body)
+		return
+	}
+
+	q := r.URL.Query()
+
+	s.lock.Lock()
+	ok := q.Get("auth_token") == envelope.UserAuthToken && q.Get("user_id") == s.userID && s.authorized(envelope.UserAuthToken)
+	s.lock.Unlock()
+
+	if !ok {
+		writeLegacyError(w, errorCodeInvalidAuth, "INVALID_AUTH_TOKEN")
+		return
+	}
+
+	s.handleLegacyCall(w, r, legacyCall{method: method, body: body})
+}
+
+func (s *Server) legacyPartnerLogin(w http.ResponseWriter, raw []byte) {
+	req := api.LegacyPartnerLoginRequest{}
+	if err := json.Unmarshal(raw, &req); err != nil {
+		writeLegacyError(w, 0, err.Error())
+		return
+	}
+
+	s.lock.Lock()
+	token := s.issueToken(true)
+	syncTime := s.now().Unix()
+	s.lock.Unlock()
+
+	writeLegacyResult(w, api.LegacyPartnerLoginResponseResult{
+		EncryptedSyncTime: legacyEncryptSyncTime(syncTime),
+		PartnerID:         token,
+		PartnerAuthToken:  token,
+	})
+}
+
+func (s *Server) legacyUserLogin(w http.ResponseWriter, r *http.Request, body []byte) {
+	req := api.LegacyUserLoginRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeLegacyError(w, 0, err.Error())
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	q := r.URL.Query()
+	partner, ok := s.sessions[req.PartnerAuthToken]
+	if !ok || !partner.partner || q.Get("auth_token") != req.PartnerAuthToken || q.Get("partner_id") != req.PartnerAuthToken {
+		writeLegacyError(w, errorCodeInvalidAuth, "INVALID_PARTNER_TOKEN")
+		return
+	}
+
+	token, ok := s.login(req.Username, req.Password)
+	if !ok {
+		writeLegacyError(w, errorCodeInvalidCredentials, "INVALID_LOGIN")
+		return
+	}
+
+	writeLegacyResult(w, api.LegacyUserLoginResponseResult{
+		UserID:        s.userID,
+		UserAuthToken: token,
+	})
+}
+
+func (s *Server) handleLegacyCall(w http.ResponseWriter, r *http.Request, c legacyCall) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch c.method {
+	case "user.getStationListChecksum":
+		writeLegacyResult(w, legacyChecksumResponse{Checksum: strconv.Itoa(len(s.stations))})
+	case "user.getStationList":
+		result := api.LegacyStationListResponse{}
+		for _, station := range s.stations {
+			result.Stations = append(result.Stations, api.LegacyStation{
+				StationID:    station.PandoraId,
+				StationToken: station.ID,
+				StationName:  station.Name,
+				DateCreated:  api.LegacyDate{Time: station.CreatedAt.UnixNano() / 1e6},
+			})
+		}
+
+		writeLegacyResult(w, result)
+	case "station.getPlaylist":
+		req := api.LegacyPlaylistRequest{}
+		if !decodeLegacyRequest(w, c, &req) {
+			return
+		}
+
+		station, ok := s.station(req.StationToken)
+		if !ok {
+			writeLegacyError(w, errorCodeStationNotFound, "STATION_DOES_NOT_EXIST")
+			return
+		}
+
+		result := api.LegacyPlaylistResponse{}
+		for _, t := range s.nextTracks(baseURL(r), station) {
+			item := api.LegacyPlaylistItem{
+				TrackToken:    t.TrackToken,
+				StationID:     station.PandoraId,
+				ArtistName:    t.ArtistName,
+				AlbumName:     t.AlbumTitle,
+				SongName:      t.SongTitle,
+				TrackGain:     "0.00",
+				TrackLength:   t.TrackLengthSeconds,
+				AllowFeedback: t.AllowFeedback,
+				AudioURLMap: map[string]api.LegacyAudioURL{
+					"highQuality": {
+						Bitrate:  "64",
+						Encoding: string(t.AudioEncoding),
+						AudioURL: t.AudioUrl,
+						Protocol: "http",
+					},
+				},
+			}
+
+			if req.AdditionalAudioURL != "" {
+				item.AdditionalAudioURL, _ = json.Marshal(t.AudioUrl)
+			}
+
+			result.Items = append(result.Items, item)
+		}
+
+		writeLegacyResult(w, result)
+	case "station.addFeedback":
+		req := api.LegacyAddFeedbackRequest{}
+		if !decodeLegacyRequest(w, c, &req) {
+			return
+		}
+
+		f, ok := s.addFeedback(req.TrackToken, req.IsPositive)
+		if !ok {
+			writeLegacyError(w, errorCodeCallNotAllowed, "UNKNOWN_TRACK")
+			return
+		}
+
+		writeLegacyResult(w, api.LegacyFeedback{
+			FeedbackID: f.ID,
+			IsPositive: f.IsPositive,
+			MusicToken: f.PandoraId,
+			SongName:   f.SongTitle,
+			ArtistName: f.ArtistName,
+		})
+	case "user.sleepSong":
+		req := api.LegacyTrackRequest{}
+		if !decodeLegacyRequest(w, c, &req) {
+			return
+		}
+
+		if _, ok := s.tracks[req.TrackToken]; !ok {
+			writeLegacyError(w, errorCodeCallNotAllowed, "UNKNOWN_TRACK")
+			return
+		}
+
+		s.tired[req.TrackToken] = true
+		writeLegacyResult(w, nil)
+	case "track.explainTrack":
+		req := api.LegacyTrackRequest{}
+		if !decodeLegacyRequest(w, c, &req) {
+			return
+		}
+
+		t, ok := s.tracks[req.TrackToken]
+		if !ok {
+			writeLegacyError(w, errorCodeCallNotAllowed, "UNKNOWN_TRACK")
+			return
+		}
+
+		result := api.LegacyExplainTrackResponse{}
+		for _, trait := range explain(t) {
+			result.Explanations = append(result.Explanations, api.LegacyExplanation{
+				FocusTraitID:   uuid.Must(uuid.NewRandom()).String(),
+				FocusTraitName: trait,
+			})
+		}
+
+		writeLegacyResult(w, result)
+	default:
+		writeLegacyError(w, errorCodeCallNotAllowed, fmt.Sprintf("method %s is not supported", c.method))
+	}
+}
+
+func decodeLegacyRequest(w http.ResponseWriter, c legacyCall, v interface{}) bool {
+	if err := json.Unmarshal(c.body, v); err != nil {
+		writeLegacyError(w, 0, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeLegacyResult(w http.ResponseWriter, result interface{}) {
+	writeJSON(w, legacyResult{
+		LegacyResponse: api.LegacyResponse{Stat: "ok"},
+		Result:         result,
+	})
+}
+
+func writeLegacyError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, legacyResult{
+		LegacyResponse: api.LegacyResponse{
+			Stat:    "fail",
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+// legacyDecrypt decodes an encrypted request body and strips the padding
+func legacyDecrypt(raw []byte) ([]byte, error) {
+	encrypted, err := hex.DecodeString(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+
+	if len(encrypted)%blowfishBlockSize != 0 {
+		return nil, fmt.Errorf("decode request: not a multiple of the block size")
+	}
+
+	decrypted := make([]byte, len(encrypted))
+	for bs := 0; bs < len(encrypted); bs += blowfishBlockSize {
+		legacyRequestCipher.Decrypt(decrypted[bs:bs+blowfishBlockSize], encrypted[bs:bs+blowfishBlockSize])
+	}
+
+	return bytes.TrimRight(decrypted, "\x00"), nil
+}
+
+// legacyEncryptSyncTime encrypts the sync time returned by the partner login.
+// Like pandora, the timestamp is prefixed with four bytes of garbage.
+func legacyEncryptSyncTime(syncTime int64) string {
+	plain := []byte(fmt.Sprintf("\x8a\x1f\x03\x7e%d", syncTime))
+	plain = append(plain, make([]byte, blowfishBlockSize-(len(plain)%blowfishBlockSize))...)
+
+	encrypted := make([]byte, len(plain))
+	for bs := 0; bs < len(plain); bs += blowfishBlockSize {
+		legacyResponseCipher.Encrypt(encrypted[bs:bs+blowfishBlockSize], plain[bs:bs+blowfishBlockSize])
+	}
+
+	return hex.EncodeToString(encrypted)
+}
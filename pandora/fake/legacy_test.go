@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nlowe/mousiki/pandora/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Legacy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		_, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		err := api.NewLegacyClient(opts...).LegacyLogin(ctx, "un", "wrong")
+		require.True(t, errors.Is(err, api.ErrInvalidCredentials))
+	})
+
+	t.Run("Playback", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		station := fake.AddStation("Fake Radio")
+
+		sut := api.NewLegacyClient(opts...)
+		require.NoError(t, sut.Login(ctx, "un", "pw"))
+
+		stations, err := sut.GetStations(ctx)
+		require.NoError(t, err)
+		require.Len(t, stations, 1)
+		require.Equal(t, station.ID, stations[0].ID)
+		require.Equal(t, station.Name, stations[0].Name)
+
+		fragment, err := sut.GetMoreTracks(ctx, station.ID, api.FragmentRequestReasonNormal)
+		require.NoError(t, err)
+		require.Len(t, fragment.Tracks, fragmentSize)
+
+		track := fragment.Tracks[0]
+		require.NotEmpty(t, track.AudioUrl)
+
+		feedback, err := sut.AddFeedback(ctx, track.TrackToken, false)
+		require.NoError(t, err)
+		require.False(t, feedback.IsPositive)
+		require.Len(t, fake.Feedback(station.ID), 1)
+
+		require.NoError(t, sut.AddTired(ctx, fragment.Tracks[1].TrackToken))
+		require.True(t, fake.IsTired(fragment.Tracks[1].TrackToken))
+
+		narrative, err := sut.GetNarrative(ctx, station.ID, track.MusicId)
+		require.NoError(t, err)
+		require.NotEmpty(t, narrative.FocusTraits)
+	})
+
+	t.Run("Token Expiry", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		fake.AddStation("Fake Radio")
+
+		sut := api.NewLegacyClient(opts...)
+		require.NoError(t, sut.Login(ctx, "un", "pw"))
+
+		fake.ExpireTokens()
+
+		stations, err := sut.GetStations(ctx)
+		require.NoError(t, err)
+		require.Len(t, stations, 1)
+		require.Equal(t, 2, fake.Logins(), "The client should log in again once the token expires")
+	})
+
+	t.Run("Resume Session", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		first := api.NewLegacyClient(opts...)
+		require.NoError(t, first.Login(ctx, "un", "pw"))
+
+		session, err := first.Session()
+		require.NoError(t, err)
+
+		require.NoError(t, api.NewLegacyClient(opts...).ResumeSession(ctx, session))
+		require.Equal(t, 1, fake.Logins())
+
+		fake.ExpireTokens()
+		require.True(t, errors.Is(api.NewLegacyClient(opts...).ResumeSession(ctx, session), api.ErrInvalidAuth))
+	})
+}
@@ -0,0 +1,290 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/nlowe/mousiki/pandora/api"
+)
+
+const (
+	csrfCookieName = "csrftoken"
+
+	errorCodeInvalidAuth        = 1001
+	errorCodeInvalidCredentials = 1002
+	errorCodeStationNotFound    = 1006
+	errorCodeCallNotAllowed     = 1008
+)
+
+type restError struct {
+	Code        int    `json:"errorCode"`
+	ErrorString string `json:"errorString"`
+	Message     string `json:"message"`
+}
+
+// restHandlerFunc handles an authorized REST call. r.Body has not been read
+// yet, decode it with decodeRequest.
+type restHandlerFunc func(w http.ResponseWriter, r *http.Request)
+
+func (s *Server) restHandler() http.Handler {
+	m := http.NewServeMux()
+
+	m.HandleFunc("/v1/auth/login", s.rest(false, s.handleLogin))
+	m.HandleFunc("/v1/station/getStations", s.rest(true, s.handleGetStations))
+	m.HandleFunc("/v1/playlist/getFragment", s.rest(true, s.handleGetFragment))
+	m.HandleFunc("/v1/station/addFeedback", s.rest(true, s.handleAddFeedback))
+	m.HandleFunc("/v1/station/getStationFeedback", s.rest(true, s.handleGetStationFeedback))
+	m.HandleFunc("/v1/listener/addTiredSong", s.rest(true, s.handleAddTired))
+	m.HandleFunc("/v1/playlist/narrative", s.rest(true, s.handleNarrative))
+
+	return m
+}
+
+// rest checks the method, CSRF token, and (if requireAuth is set) the auth
+// token of a REST call before passing it on to f
+func (s *Server) rest(requireAuth bool, f restHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.log.WithField("path", r.URL.Path).Debug("REST Call")
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		cookie, err := r.Cookie(csrfCookieName)
+		if err != nil || cookie.Value == "" || r.Header.Get("X-CsrfToken") != cookie.Value {
+			writeRESTError(w, http.StatusForbidden, 0, "CSRF_TOKEN_MISMATCH", "missing or mismatched CSRF token")
+			return
+		}
+
+		if requireAuth {
+			s.lock.Lock()
+			ok := s.authorized(r.Header.Get("X-AuthToken"))
+			s.lock.Unlock()
+
+			if !ok {
+				writeRESTError(w, http.StatusUnauthorized, errorCodeInvalidAuth, "INVALID_AUTH_TOKEN", "auth token is invalid or expired")
+				return
+			}
+		}
+
+		f(w, r)
+	}
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	req := api.LoginRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Resuming a session exchanges a token that is still valid for a new one
+	if req.ExistingAuthToken != "" {
+		if !s.authorized(req.ExistingAuthToken) {
+			writeRESTError(w, http.StatusUnauthorized, errorCodeInvalidAuth, "INVALID_AUTH_TOKEN", "auth token is invalid or expired")
+			return
+		}
+
+		s.writeLoginResponse(w, s.issueToken(false))
+		return
+	}
+
+	token, ok := s.login(req.Username, req.Password)
+	if !ok {
+		writeRESTError(w, http.StatusBadRequest, errorCodeInvalidCredentials, "AUTH_INVALID_USERNAME_PASSWORD", "invalid username or password")
+		return
+	}
+
+	s.writeLoginResponse(w, token)
+}
+
+// writeLoginResponse responds to a login with a new auth token. The caller
+// must hold lock.
+func (s *Server) writeLoginResponse(w http.ResponseWriter, token string) {
+	writeJSON(w, api.LoginResponse{
+		AuthToken: token,
+		Username:  s.username,
+		WebName:   strings.SplitN(s.username, "@", 2)[0],
+	})
+}
+
+func (s *Server) handleGetStations(w http.ResponseWriter, r *http.Request) {
+	req := api.StationRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	start := req.StartIndex
+	if start > len(s.stations) {
+		start = len(s.stations)
+	}
+
+	end := len(s.stations)
+	if req.PageSize > 0 && start+req.PageSize < end {
+		end = start + req.PageSize
+	}
+
+	writeJSON(w, api.StationResponse{
+		TotalStations: len(s.stations),
+		SortedBy:      api.StationSortOrderLastPlayed,
+		Index:         start,
+		Stations:      s.stations[start:end],
+	})
+}
+
+func (s *Server) handleGetFragment(w http.ResponseWriter, r *http.Request) {
+	req := api.GetPlaylistFragmentRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	station, ok := s.station(req.StationID)
+	if !ok {
+		writeRESTError(w, http.StatusBadRequest, errorCodeStationNotFound, "STATION_DOES_NOT_EXIST", "station does not exist")
+		return
+	}
+
+	var tracks []pandora.Track
+	for _, t := range s.nextTracks(baseURL(r), station) {
+		tracks = append(tracks, t.Track)
+	}
+
+	writeJSON(w, api.GetPlaylistFragmentResponse{
+		Tracks:          tracks,
+		IsBingeSkipping: s.skipsRemaining(station.ID) == 0,
+	})
+}
+
+func (s *Server) handleAddFeedback(w http.ResponseWriter, r *http.Request) {
+	req := api.AddFeedbackRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, ok := s.addFeedback(req.TrackToken, req.IsPositive)
+	if !ok {
+		writeRESTError(w, http.StatusBadRequest, errorCodeCallNotAllowed, "UNKNOWN_TRACK", "unknown track token")
+		return
+	}
+
+	writeJSON(w, api.AddFeedbackResponse{
+		ID:         f.ID,
+		StationId:  f.StationId,
+		MusicId:    f.MusicId,
+		PandoraId:  f.PandoraId,
+		IsPositive: f.IsPositive,
+	})
+}
+
+func (s *Server) handleGetStationFeedback(w http.ResponseWriter, r *http.Request) {
+	req := api.StationFeedbackRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	feedback := s.feedback[req.StationID]
+
+	start := req.StartIndex
+	if start > len(feedback) {
+		start = len(feedback)
+	}
+
+	end := len(feedback)
+	if req.PageSize > 0 && start+req.PageSize < end {
+		end = start + req.PageSize
+	}
+
+	writeJSON(w, api.StationFeedbackResponse{
+		Total:    len(feedback),
+		Feedback: feedback[start:end],
+	})
+}
+
+func (s *Server) handleAddTired(w http.ResponseWriter, r *http.Request) {
+	req := api.AddTiredRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.tracks[req.TrackToken]; !ok {
+		writeRESTError(w, http.StatusBadRequest, errorCodeCallNotAllowed, "UNKNOWN_TRACK", "unknown track token")
+		return
+	}
+
+	s.tired[req.TrackToken] = true
+	writeJSON(w, api.AddTiredResponse{})
+}
+
+func (s *Server) handleNarrative(w http.ResponseWriter, r *http.Request) {
+	req := api.NarrativeRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, t := range s.tracks {
+		if t.MusicId == req.MusicID && t.StationId == req.StationID {
+			writeJSON(w, narrativeFor(t))
+			return
+		}
+	}
+
+	writeRESTError(w, http.StatusBadRequest, errorCodeCallNotAllowed, "UNKNOWN_TRACK", "unknown track")
+}
+
+func narrativeFor(t *track) pandora.Narrative {
+	traits := explain(t)
+
+	return pandora.Narrative{
+		Intro:       "Based on what you've told us so far, we're playing this track because it features:",
+		FocusTraits: traits,
+		Paragraph:   "We're playing this track because it features " + strings.Join(traits, ", ") + ".",
+	}
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeRESTError(w, http.StatusBadRequest, 0, "BAD_REQUEST", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeRESTError(w http.ResponseWriter, status, code int, errorString, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(restError{
+		Code:        code,
+		ErrorString: errorString,
+		Message:     message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,151 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/nlowe/mousiki/pandora/api"
+	"github.com/nlowe/mousiki/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFakeTest(t *testing.T, opts ...Option) (*Server, *httptest.Server, []api.Option) {
+	fake := New("un", "pw", append([]Option{WithLogger(testutil.NopLogger())}, opts...)...)
+	sv := httptest.NewServer(fake.Handler())
+
+	return fake, sv, []api.Option{
+		api.WithBaseURL(sv.URL),
+		api.WithLegacyURL(sv.URL + LegacyPath),
+		api.WithLogger(testutil.NopLogger()),
+	}
+}
+
+func TestServer_REST(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		_, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		err := api.NewClient(opts...).Login(ctx, "un", "wrong")
+		require.True(t, errors.Is(err, api.ErrInvalidCredentials))
+	})
+
+	t.Run("Playback", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		station := fake.AddStation("Fake Radio")
+		fake.AddStation("Other Radio")
+
+		sut := api.NewClient(opts...)
+		require.NoError(t, sut.Login(ctx, "un", "pw"))
+
+		stations, err := sut.GetStations(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Fake Radio", "Other Radio"}, []string{stations[0].Name, stations[1].Name})
+
+		fragment, err := sut.GetMoreTracks(ctx, station.ID, api.FragmentRequestReasonNormal)
+		require.NoError(t, err)
+		require.Len(t, fragment.Tracks, fragmentSize)
+		require.False(t, fragment.IsBingeSkipping)
+
+		track := fragment.Tracks[0]
+		require.Equal(t, station.ID, track.StationId)
+		require.True(t, track.AllowSkip)
+
+		feedback, err := sut.AddFeedback(ctx, track.TrackToken, true)
+		require.NoError(t, err)
+		require.Equal(t, []pandora.Feedback{{
+			ID:         feedback.ID,
+			StationId:  station.ID,
+			MusicId:    track.MusicId,
+			PandoraId:  track.PandoraId,
+			IsPositive: true,
+			SongTitle:  track.SongTitle,
+			ArtistName: track.ArtistName,
+		}}, fake.Feedback(station.ID))
+
+		require.NoError(t, sut.AddTired(ctx, fragment.Tracks[1].TrackToken))
+		require.True(t, fake.IsTired(fragment.Tracks[1].TrackToken))
+
+		narrative, err := sut.GetNarrative(ctx, station.ID, track.MusicId)
+		require.NoError(t, err)
+		require.NotEmpty(t, narrative.FocusTraits)
+
+		require.NoError(t, sut.ReportAudioReceipt(ctx, track))
+		require.NoError(t, sut.ReportSkip(ctx, track))
+		receipts, skips := fake.Reports(track.TrackToken)
+		require.Equal(t, 1, receipts)
+		require.Equal(t, 1, skips)
+	})
+
+	t.Run("Unknown Station", func(t *testing.T) {
+		_, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		sut := api.NewClient(opts...)
+		require.NoError(t, sut.Login(ctx, "un", "pw"))
+
+		_, err := sut.GetMoreTracks(ctx, "nope", api.FragmentRequestReasonNormal)
+		require.True(t, errors.Is(err, api.ErrStationDoesNotExist))
+	})
+
+	t.Run("Skip Limit", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t, WithSkipLimit(1, time.Hour))
+		defer sv.Close()
+
+		station := fake.AddStation("Fake Radio")
+
+		sut := api.NewClient(opts...)
+		require.NoError(t, sut.Login(ctx, "un", "pw"))
+
+		fragment, err := sut.GetMoreTracks(ctx, station.ID, api.FragmentRequestReasonNormal)
+		require.NoError(t, err)
+		require.NoError(t, sut.ReportSkip(ctx, fragment.Tracks[0]))
+
+		fragment, err = sut.GetMoreTracks(ctx, station.ID, api.FragmentRequestReasonSkip)
+		require.NoError(t, err)
+		require.True(t, fragment.IsBingeSkipping)
+		require.False(t, fragment.Tracks[0].AllowSkip)
+	})
+
+	t.Run("Token Expiry", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		fake.AddStation("Fake Radio")
+
+		sut := api.NewClient(opts...)
+		require.NoError(t, sut.Login(ctx, "un", "pw"))
+
+		fake.ExpireTokens()
+
+		stations, err := sut.GetStations(ctx)
+		require.NoError(t, err)
+		require.Len(t, stations, 1)
+		require.Equal(t, 2, fake.Logins(), "The client should log in again once the token expires")
+	})
+
+	t.Run("Resume Session", func(t *testing.T) {
+		fake, sv, opts := setupFakeTest(t)
+		defer sv.Close()
+
+		first := api.NewClient(opts...)
+		require.NoError(t, first.Login(ctx, "un", "pw"))
+
+		session, err := first.Session()
+		require.NoError(t, err)
+
+		sut := api.NewClient(opts...)
+		require.NoError(t, sut.ResumeSession(ctx, session))
+		require.Equal(t, 1, fake.Logins())
+
+		fake.ExpireTokens()
+		require.Error(t, api.NewClient(opts...).ResumeSession(ctx, session))
+	})
+}
@@ -0,0 +1,411 @@
+// Package fake implements an in-process stand-in for pandora's REST and legacy
+// JSON APIs. It keeps enough state (stations, feedback, skip limits, and
+// expiring auth tokens) to exercise the real clients in pandora/api end to end,
+// and serves generated audio so tracks can actually be played.
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nlowe/mousiki/pandora"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LegacyPath is where the legacy JSON API is served, pass it to
+	// api.WithLegacyURL along with the server URL
+	LegacyPath = "/services/json/"
+
+	fragmentSize = 4
+
+	defaultTokenTTL    = time.Hour
+	defaultSkipLimit   = 6
+	defaultSkipWindow  = time.Hour
+	defaultAudioLength = 30 * time.Second
+)
+
+// Option customizes a Server created by New
+type Option func(s *Server)
+
+// WithTokenTTL expires auth tokens ttl after they were issued
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.tokenTTL = ttl
+	}
+}
+
+// WithSkipLimit only lets limit tracks be skipped on each station within
+// window. Once the limit is reached, tracks can't be skipped and fragments
+// report binge skipping.
+func WithSkipLimit(limit int, window time.Duration) Option {
+	return func(s *Server) {
+		s.skipLimit = limit
+		s.skipWindow = window
+	}
+}
+
+// WithAudioLength sets how long the generated audio for each track is
+func WithAudioLength(d time.Duration) Option {
+	return func(s *Server) {
+		s.audioLength = d
+	}
+}
+
+// WithClock makes the server use now to tell the time, for expiring tokens
+// and skips
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithLogger logs requests with log instead of the standard logger
+func WithLogger(log logrus.FieldLogger) Option {
+	return func(s *Server) {
+		s.log = log
+	}
+}
+
+// session is an auth token issued by one of the login calls
+type session struct {
+	userID  string
+	partner bool
+	expires time.Time
+}
+
+// track is a track that was handed out in a fragment or playlist
+type track struct {
+	pandora.Track
+
+	// tone is the pitch of the generated audio in Hz
+	tone float64
+}
+
+// Server is a fake pandora with a single listener account. Use Handler to
+// serve it, e.g. with httptest.NewServer.
+type Server struct {
+	lock sync.Mutex
+
+	username string
+	password string
+	userID   string
+
+	tokenTTL    time.Duration
+	skipLimit   int
+	skipWindow  time.Duration
+	audioLength time.Duration
+
+	sessions map[string]session
+	stations []pandora.Station
+	tracks   map[string]*track
+	feedback map[string][]pandora.Feedback
+	tired    map[string]bool
+	skips    map[string][]time.Time
+	receipts map[string]int
+	skipped  map[string]int
+	logins   int
+
+	nextTrack int
+
+	now func() time.Time
+	log logrus.FieldLogger
+}
+
+// New creates a fake pandora that accepts username and password
+func New(username, password string, opts ...Option) *Server {
+	s := &Server{
+		username: username,
+		password: password,
+		userID:   uuid.Must(uuid.NewRandom()).String(),
+
+		tokenTTL:    defaultTokenTTL,
+		skipLimit:   defaultSkipLimit,
+		skipWindow:  defaultSkipWindow,
+		audioLength: defaultAudioLength,
+
+		sessions: map[string]session{},
+		tracks:   map[string]*track{},
+		feedback: map[string][]pandora.Feedback{},
+		tired:    map[string]bool{},
+		skips:    map[string][]time.Time{},
+		receipts: map[string]int{},
+		skipped:  map[string]int{},
+
+		now: time.Now,
+		log: logrus.WithField("prefix", "fakePandora"),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Handler serves the REST API under /api, the legacy API under LegacyPath,
+// and the audio and report URLs handed out with tracks
+func (s *Server) Handler() http.Handler {
+	m := http.NewServeMux()
+
+	m.HandleFunc("/", s.handleCSRF)
+	m.Handle("/api/", http.StripPrefix("/api", s.restHandler()))
+	m.HandleFunc(LegacyPath, s.handleLegacy)
+	m.HandleFunc("/audio/", s.handleAudio)
+	m.HandleFunc("/report/", s.handleReport)
+
+	return m
+}
+
+// AddStation adds a station to the listener's account
+func (s *Server) AddStation(name string) pandora.Station {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	station := pandora.Station{
+		ID:        uuid.Must(uuid.NewRandom()).String(),
+		PandoraId: fmt.Sprintf("ST:0:%s", uuid.Must(uuid.NewRandom())),
+		Name:      name,
+		CreatedAt: s.now().UTC(),
+	}
+
+	s.stations = append(s.stations, station)
+	return station
+}
+
+// Stations lists the stations on the listener's account
+func (s *Server) Stations() []pandora.Station {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := make([]pandora.Station, len(s.stations))
+	copy(result, s.stations)
+
+	return result
+}
+
+// Feedback lists the thumbs up and thumbs down given to tracks on a station
+func (s *Server) Feedback(stationId string) []pandora.Feedback {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := make([]pandora.Feedback, len(s.feedback[stationId]))
+	copy(result, s.feedback[stationId])
+
+	return result
+}
+
+// IsTired returns true if the track was marked as tired
+func (s *Server) IsTired(trackToken string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.tired[trackToken]
+}
+
+// Reports returns how many audio receipts and skips were reported for a track
+func (s *Server) Reports(trackToken string) (receipts, skips int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.receipts[trackToken], s.skipped[trackToken]
+}
+
+// Logins returns how many times the listener logged in with a password
+func (s *Server) Logins() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.logins
+}
+
+// ExpireTokens expires every auth token that was issued so far, as if they
+// timed out
+func (s *Server) ExpireTokens() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for token, sess := range s.sessions {
+		sess.expires = s.now()
+		s.sessions[token] = sess
+	}
+}
+
+// handleCSRF hands out the CSRF cookie the REST client picks up before its
+// first request
+func (s *Server) handleCSRF(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:  csrfCookieName,
+		Value: uuid.Must(uuid.NewRandom()).String(),
+		Path:  "/",
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// login checks the listener's credentials and issues a new user auth token.
+// The caller must hold lock.
+func (s *Server) login(username, password string) (string, bool) {
+	if username != s.username || password != s.password {
+		return "", false
+	}
+
+	s.logins++
+	return s.issueToken(false), true
+}
+
+// issueToken creates a new auth token. The caller must hold lock.
+func (s *Server) issueToken(partner bool) string {
+	token := uuid.Must(uuid.NewRandom()).String()
+	s.sessions[token] = session{
+		userID:  s.userID,
+		partner: partner,
+		expires: s.now().Add(s.tokenTTL),
+	}
+
+	return token
+}
+
+// authorized returns true if token is a user auth token that hasn't expired.
+// The caller must hold lock.
+func (s *Server) authorized(token string) bool {
+	sess, ok := s.sessions[token]
+	if !ok || sess.partner {
+		return false
+	}
+
+	if !s.now().Before(sess.expires) {
+		delete(s.sessions, token)
+		return false
+	}
+
+	return true
+}
+
+// station looks up a station by ID. The caller must hold lock.
+func (s *Server) station(stationId string) (pandora.Station, bool) {
+	for _, station := range s.stations {
+		if station.ID == stationId {
+			return station, true
+		}
+	}
+
+	return pandora.Station{}, false
+}
+
+// skipsRemaining expires old skips on a station and returns how many more
+// tracks can be skipped. The caller must hold lock.
+func (s *Server) skipsRemaining(stationId string) int {
+	cutoff := s.now().Add(-s.skipWindow)
+
+	skips := s.skips[stationId]
+	for len(skips) > 0 && !skips[0].After(cutoff) {
+		skips = skips[1:]
+	}
+
+	s.skips[stationId] = skips
+	if n := s.skipLimit - len(skips); n > 0 {
+		return n
+	}
+
+	return 0
+}
+
+// nextTracks generates the next few tracks for a station. The caller must
+// hold lock.
+func (s *Server) nextTracks(baseURL string, station pandora.Station) []*track {
+	allowSkip := s.skipsRemaining(station.ID) > 0
+
+	var result []*track
+	for i := 0; i < fragmentSize; i++ {
+		s.nextTrack++
+		n := s.nextTrack
+
+		token := uuid.Must(uuid.NewRandom()).String()
+		t := &track{tone: toneFor(n)}
+
+		t.MusicId = fmt.Sprintf("S%d", n)
+		t.PandoraId = fmt.Sprintf("TR:%d", n)
+		t.StationId = station.ID
+		t.ArtistMusicId = fmt.Sprintf("R%d", n%7)
+		t.TrackToken = token
+		t.TrackType = pandora.TrackTypeTrack
+		t.TrackKey.TrackID = t.MusicId
+		t.TrackKey.TrackType = pandora.TrackTypeTrack
+		t.TrackKey.SpinId = uuid.Must(uuid.NewRandom()).String()
+
+		t.SongTitle = fmt.Sprintf("Fake Song %d", n)
+		t.ArtistName = fmt.Sprintf("Fake Artist %d", n%7)
+		t.AlbumTitle = fmt.Sprintf("%s Sessions", strings.TrimSuffix(station.Name, " Radio"))
+
+		t.AudioUrl = fmt.Sprintf("%s/audio/%s.wav", baseURL, token)
+		t.AudioEncoding = pandora.AudioFormatAACPlus
+		t.TrackLengthSeconds = int(s.audioLength.Seconds())
+		t.AudioReceiptURL = fmt.Sprintf("%s/report/receipt/%s", baseURL, token)
+		t.AudioSkipURL = fmt.Sprintf("%s/report/skip/%s", baseURL, token)
+
+		t.AllowFeedback = true
+		t.AllowTiredOfTrack = true
+		t.AllowStartStationFromTrack = true
+		t.AllowSkip = allowSkip
+
+		s.tracks[token] = t
+		result = append(result, t)
+	}
+
+	return result
+}
+
+// addFeedback rates a track that was handed out earlier. The caller must
+// hold lock.
+func (s *Server) addFeedback(trackToken string, isPositive bool) (pandora.Feedback, bool) {
+	t, ok := s.tracks[trackToken]
+	if !ok {
+		return pandora.Feedback{}, false
+	}
+
+	// Rating a track again replaces the previous rating
+	existing := s.feedback[t.StationId][:0]
+	for _, f := range s.feedback[t.StationId] {
+		if f.MusicId != t.MusicId {
+			existing = append(existing, f)
+		}
+	}
+
+	f := pandora.Feedback{
+		ID:         uuid.Must(uuid.NewRandom()).String(),
+		StationId:  t.StationId,
+		MusicId:    t.MusicId,
+		PandoraId:  t.PandoraId,
+		IsPositive: isPositive,
+		SongTitle:  t.SongTitle,
+		ArtistName: t.ArtistName,
+	}
+
+	s.feedback[t.StationId] = append(existing, f)
+	return f, true
+}
+
+// explain makes up a reason for playing a track
+func explain(t *track) []string {
+	traits := []string{"electronic instrumentation", "a steady beat", "a pure sine wave"}
+	return traits[:1+len(t.MusicId)%len(traits)]
+}
+
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
@@ -0,0 +1,25 @@
+package pandora
+
+import "fmt"
+
+// Feedback is a thumbs up or thumbs down given to a track on a station
+type Feedback struct {
+	ID         string `json:"feedbackId"`
+	StationId  string `json:"stationId"`
+	MusicId    string `json:"musicId"`
+	PandoraId  string `json:"pandoraId"`
+	IsPositive bool   `json:"isPositive"`
+
+	SongTitle  string `json:"songTitle"`
+	ArtistName string `json:"artistName"`
+	AlbumTitle string `json:"albumTitle"`
+}
+
+func (f Feedback) String() string {
+	rating := "Banned"
+	if f.IsPositive {
+		rating = "Loved"
+	}
+
+	return fmt.Sprintf("[%s] %s - %s", rating, f.SongTitle, f.ArtistName)
+}
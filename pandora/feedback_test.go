@@ -0,0 +1,20 @@
+package pandora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedback_String(t *testing.T) {
+	sut := Feedback{
+		SongTitle:  "DummySong",
+		ArtistName: "DummyArtist",
+		IsPositive: true,
+	}
+
+	require.Equal(t, "[Loved] DummySong - DummyArtist", sut.String())
+
+	sut.IsPositive = false
+	require.Equal(t, "[Banned] DummySong - DummyArtist", sut.String())
+}
@@ -0,0 +1,16 @@
+package pandora
+
+// GenreStation is a station pandora curates around a genre. It needs to be
+// added to the station list with CreateStation before it can be played.
+type GenreStation struct {
+	// PandoraId is passed to CreateStation to add this station to the
+	// station list
+	PandoraId string `json:"pandoraId"`
+	Name      string `json:"name"`
+}
+
+// GenreCategory groups related genre stations together
+type GenreCategory struct {
+	Name     string         `json:"name"`
+	Stations []GenreStation `json:"stations"`
+}
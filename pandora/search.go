@@ -0,0 +1,74 @@
+package pandora
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SearchResultType string
+
+const (
+	SearchResultTypeArtist SearchResultType = "AR"
+	SearchResultTypeTrack  SearchResultType = "TR"
+	SearchResultTypeGenre  SearchResultType = "SF"
+)
+
+// SearchResult is a single artist, track, or genre that a station can be
+// created from
+type SearchResult struct {
+	// PandoraId is passed to CreateStation to create a station from this result
+	PandoraId string           `json:"pandoraId"`
+	Type      SearchResultType `json:"type"`
+
+	Name       string `json:"name"`
+	ArtistName string `json:"artistName"`
+}
+
+type SearchResults struct {
+	Artists []SearchResult
+	Tracks  []SearchResult
+	Genres  []SearchResult
+}
+
+// All returns every result, artists first followed by tracks and genres
+func (r SearchResults) All() []SearchResult {
+	result := make([]SearchResult, 0, len(r.Artists)+len(r.Tracks)+len(r.Genres))
+	result = append(result, r.Artists...)
+	result = append(result, r.Tracks...)
+	return append(result, r.Genres...)
+}
+
+// FindArtist returns the artist result named name, ignoring case
+func (r SearchResults) FindArtist(name string) (SearchResult, bool) {
+	for _, a := range r.Artists {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+
+	return SearchResult{}, false
+}
+
+// FindTrack returns the track result named title by artist, ignoring case
+func (r SearchResults) FindTrack(title, artist string) (SearchResult, bool) {
+	for _, t := range r.Tracks {
+		if strings.EqualFold(t.Name, title) && strings.EqualFold(t.ArtistName, artist) {
+			return t, true
+		}
+	}
+
+	return SearchResult{}, false
+}
+
+func (r SearchResult) String() string {
+	switch r.Type {
+	case SearchResultTypeArtist:
+		return fmt.Sprintf("[Artist] %s", r.Name)
+	case SearchResultTypeTrack:
+		return fmt.Sprintf("[Track] %s - %s", r.Name, r.ArtistName)
+	case SearchResultTypeGenre:
+		return fmt.Sprintf("[Genre] %s", r.Name)
+	default:
+		return r.Name
+	}
+}
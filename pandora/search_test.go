@@ -0,0 +1,57 @@
+package pandora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchResult_String(t *testing.T) {
+	for _, tt := range []struct {
+		sut      SearchResult
+		expected string
+	}{
+		{sut: SearchResult{Type: SearchResultTypeArtist, Name: "DummyArtist"}, expected: "[Artist] DummyArtist"},
+		{sut: SearchResult{Type: SearchResultTypeTrack, Name: "DummySong", ArtistName: "DummyArtist"}, expected: "[Track] DummySong - DummyArtist"},
+		{sut: SearchResult{Type: SearchResultTypeGenre, Name: "DummyGenre"}, expected: "[Genre] DummyGenre"},
+		{sut: SearchResult{Type: "??", Name: "Unknown"}, expected: "Unknown"},
+	} {
+		t.Run(tt.expected, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.sut.String())
+		})
+	}
+}
+
+func TestSearchResults_All(t *testing.T) {
+	sut := SearchResults{
+		Artists: []SearchResult{{Name: "a"}},
+		Tracks:  []SearchResult{{Name: "t"}},
+		Genres:  []SearchResult{{Name: "g"}},
+	}
+
+	require.Equal(t, []SearchResult{{Name: "a"}, {Name: "t"}, {Name: "g"}}, sut.All())
+}
+
+func TestSearchResults_Find(t *testing.T) {
+	sut := SearchResults{
+		Artists: []SearchResult{{PandoraId: "AR:1", Name: "Dummy Artist Jr"}, {PandoraId: "AR:2", Name: "Dummy Artist"}},
+		Tracks: []SearchResult{
+			{PandoraId: "TR:1", Name: "Dummy Song", ArtistName: "Cover Band"},
+			{PandoraId: "TR:2", Name: "Dummy Song", ArtistName: "Dummy Artist"},
+		},
+	}
+
+	artist, ok := sut.FindArtist("dummy artist")
+	require.True(t, ok)
+	require.Equal(t, "AR:2", artist.PandoraId)
+
+	track, ok := sut.FindTrack("dummy song", "Dummy Artist")
+	require.True(t, ok)
+	require.Equal(t, "TR:2", track.PandoraId)
+
+	_, ok = sut.FindArtist("Someone Else")
+	require.False(t, ok)
+
+	_, ok = sut.FindTrack("Dummy Song", "Someone Else")
+	require.False(t, ok)
+}
@@ -22,3 +22,29 @@ type Station struct {
 func (s Station) String() string {
 	return fmt.Sprintf("[%s] %s", s.ID, s.Name)
 }
+
+// StationSeed is an artist, track, or genre a station plays music similar to
+type StationSeed struct {
+	SeedId    string           `json:"seedId"`
+	PandoraId string           `json:"pandoraId"`
+	Type      SearchResultType `json:"type"`
+
+	Name       string `json:"name"`
+	ArtistName string `json:"artistName"`
+}
+
+func (s StationSeed) String() string {
+	return SearchResult{Type: s.Type, Name: s.Name, ArtistName: s.ArtistName}.String()
+}
+
+// StationDetails describes how a station was created and what it has been
+// tuned with
+type StationDetails struct {
+	Station
+
+	InitialSeed StationSeed   `json:"initialSeed"`
+	Seeds       []StationSeed `json:"seeds"`
+
+	PositiveFeedbackCount int `json:"positiveFeedbackCount"`
+	NegativeFeedbackCount int `json:"negativeFeedbackCount"`
+}
@@ -14,3 +14,13 @@ func TestStation_String(t *testing.T) {
 
 	require.Equal(t, "[DummyID] Test Station", sut.String())
 }
+
+func TestStationSeed_String(t *testing.T) {
+	sut := StationSeed{
+		Type:       SearchResultTypeTrack,
+		Name:       "DummySong",
+		ArtistName: "DummyArtist",
+	}
+
+	require.Equal(t, "[Track] DummySong - DummyArtist", sut.String())
+}
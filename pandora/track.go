@@ -1,6 +1,10 @@
 package pandora
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type TrackType string
 
@@ -8,6 +12,12 @@ const (
 	TrackTypeTrack TrackType = "Track"
 )
 
+// Pandora IDs are prefixed with the kind of music they identify
+const (
+	pandoraIdPrefixTrack  = "TR:"
+	pandoraIdPrefixArtist = "AR:"
+)
+
 type TrackRating int
 
 // TODO: Verify these are correct
@@ -46,7 +56,8 @@ type Track struct {
 	AllowFeedback              bool     `json:"allowFeedback"`
 	Rights                     []string `json:"rights"`
 
-	// TODO: Do we need to call these when we download/skip the track?
+	// Requested when playback of the track starts or the track is skipped,
+	// see api.TrackReporter
 	AudioReceiptURL string `json:"audioReceiptURL"`
 	AudioSkipURL    string `json:"audioSkipUrl"`
 
@@ -69,6 +80,44 @@ type Track struct {
 	} `json:"trackKey"`
 }
 
+// Fragment is the next batch of tracks to play on a station
+type Fragment struct {
+	Tracks []Track
+
+	// IsBingeSkipping is set when pandora thinks tracks on the station are
+	// being skipped too frequently
+	IsBingeSkipping bool
+}
+
 func (t Track) String() string {
 	return fmt.Sprintf("[%s:%s] %s - %s - %s", t.TrackType, t.MusicId, t.SongTitle, t.ArtistName, t.AlbumTitle)
 }
+
+// Length is the length of the track as reported by pandora
+func (t Track) Length() time.Duration {
+	return time.Duration(t.TrackLengthSeconds) * time.Second
+}
+
+// SongSeedId is the pandora ID used to add the song as a station seed. It is
+// empty if the track doesn't have one, like tracks from the legacy API which
+// are only identified by their track token. The song has to be looked up with
+// a search instead.
+func (t Track) SongSeedId() string {
+	if strings.HasPrefix(t.PandoraId, pandoraIdPrefixTrack) {
+		return t.PandoraId
+	}
+
+	return ""
+}
+
+// ArtistSeedId is the pandora ID used to add the artist of the track as a
+// station seed. It is empty if the track doesn't have one, pandora usually
+// only tells us the artist's music ID which can't be used as a seed. The
+// artist has to be looked up with a search instead.
+func (t Track) ArtistSeedId() string {
+	if strings.HasPrefix(t.ArtistMusicId, pandoraIdPrefixArtist) {
+		return t.ArtistMusicId
+	}
+
+	return ""
+}
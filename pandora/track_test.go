@@ -2,6 +2,7 @@ package pandora
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -18,3 +19,17 @@ func TestTrack_String(t *testing.T) {
 
 	require.Equal(t, "[Track:DummyID] DummySong - DummyArtist - DummyAlbum", sut.String())
 }
+
+func TestTrack_Length(t *testing.T) {
+	require.Equal(t, 3*time.Minute+3*time.Second, Track{TrackLengthSeconds: 183}.Length())
+}
+
+func TestTrack_SeedIds(t *testing.T) {
+	sut := Track{PandoraId: "TR:1234", ArtistMusicId: "AR:5678"}
+	require.Equal(t, "TR:1234", sut.SongSeedId())
+	require.Equal(t, "AR:5678", sut.ArtistSeedId())
+
+	sut = Track{PandoraId: "some-track-token", ArtistMusicId: "R5678"}
+	require.Empty(t, sut.SongSeedId(), "Track tokens can't be used as seeds")
+	require.Empty(t, sut.ArtistSeedId(), "Music IDs can't be used as seeds")
+}